@@ -0,0 +1,297 @@
+package qbittorrent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+)
+
+// AddResult is the outcome of adding a single torrent with [Client.AddTorrents].
+type AddResult struct {
+	Source    string // File path, URL, or name given to AddFromBytes or AddFromReader
+	Hash      string // Hash of the torrent, empty when it can't be known before qBittorrent downloads it, e.g. for an http URL
+	Name      string // Name of the torrent, empty when unknown
+	Duplicate bool   // True if the torrent was already in qBittorrent, it was not sent again
+}
+
+// default limit of the wait of AddTorrents, a magnet link without peers never receives its metadata
+const defaultAddWaitTimeout = 5 * time.Minute
+
+// AddTorrentsOptions are the options of [Client.AddTorrents].
+type AddTorrentsOptions struct {
+	WaitAdded    bool          // Wait until every new torrent with a known hash is in the torrent list
+	WaitMetadata bool          // Also wait until the torrents added from a magnet link have received their metadata, implies WaitAdded
+	PollInterval time.Duration // Interval between two torrent list requests while waiting, defaults to 1.5 seconds
+	WaitTimeout  time.Duration // Maximum time waiting for the torrents, defaults to 5 minutes, the deadline of ctx also applies
+}
+
+// addEntry is a torrent of the options given to AddTorrents
+type addEntry struct {
+	result AddResult
+	path   string             // set for a .torrent file on the file system
+	url    string             // set for a URL or magnet link
+	source *torrentSource     // set for a torrent added with AddFromBytes or AddFromReader
+	magnet bool               // the metadata has to be downloaded by qBittorrent
+	meta   *metainfo.MetaInfo // parsed .torrent file, nil when it's not known locally
+}
+
+/*
+AddTorrents is like [Client.AddNewTorrentWithOptions] but reports which torrents were added.
+
+The hashes are computed locally, from the .torrent files and the magnet links. Torrents already in qBittorrent,
+or given twice, are reported as duplicates and not sent. The torrents added from an http URL are always sent, and
+have an empty hash as it's only known once qBittorrent downloaded them.
+
+The results are in the order of the .torrent files, then the torrents added with AddFromBytes and AddFromReader,
+then the URLs. The torrents added with AddFromReader are read in memory to compute their hash.
+
+With opts.WaitMetadata, torrents added stopped never receive their metadata, use
+[NewTorrentOptions.StopCondition] with [StopConditionMetadataReceived] to stop them once they have it.
+The wait fails with [context.DeadlineExceeded] after opts.WaitTimeout.
+
+When qBittorrent adds none of the torrents, the returned error matches [ErrAddFailed].
+
+# Example
+
+	torrent := qbittorrent.NewTorrent().
+		AddFromFile("ubuntu.torrent").
+		AddUrl("magnet:?xt=urn:btih:8c212779b4abde7c6bc608063a0d008b7e40ce32")
+
+	results, err := client.AddTorrents(ctx, torrent, &qbittorrent.AddTorrentsOptions{WaitMetadata: true})
+	if err != nil {
+	 panic(err)
+	}
+
+	for _, result := range results {
+	 fmt.Println(result.Hash, result.Name, result.Duplicate)
+	}
+*/
+func (c *Client) AddTorrents(ctx context.Context, torrent *NewTorrentOptions, opts *AddTorrentsOptions) (results []AddResult, err error) {
+	if opts == nil {
+		opts = &AddTorrentsOptions{}
+	}
+
+	err = torrent.Validate()
+	if err != nil {
+		return
+	}
+
+	entries, err := torrent.addEntries()
+	if err != nil {
+		return
+	}
+
+	err = c.addTorrentEntries(ctx, torrent.Data, entries, opts)
+
+	results = make([]AddResult, len(entries))
+	for i, entry := range entries {
+		results[i] = entry.result
+	}
+
+	return
+}
+
+// addTorrentEntries sends the entries that are not duplicates with the options of data, and waits for them with opts
+func (c *Client) addTorrentEntries(ctx context.Context, data map[string]string, entries []*addEntry, opts *AddTorrentsOptions) (err error) {
+	err = c.markDuplicates(ctx, entries)
+	if err != nil {
+		return
+	}
+
+	send := &NewTorrentOptions{Data: maps.Clone(data)}
+	delete(send.Data, "torrents")
+	delete(send.Data, "urls")
+	delete(send.Data, sourcesParam)
+
+	count := 0
+	for _, entry := range entries {
+		if entry.result.Duplicate {
+			continue
+		}
+		count++
+
+		switch {
+		case entry.path != "":
+			send.AddFromFile(entry.path)
+		case entry.url != "":
+			send.AddUrl(entry.url)
+		default:
+			send.sources = append(send.sources, *entry.source)
+		}
+	}
+	if count == 0 {
+		return
+	}
+
+	err = c.AddNewTorrentWithOptionsCtx(ctx, send)
+	if err == nil && (opts.WaitAdded || opts.WaitMetadata) {
+		err = c.waitAdded(ctx, entries, opts)
+	}
+
+	return
+}
+
+// addEntries lists the torrents of the options with their hash when it can be computed locally
+func (o *NewTorrentOptions) addEntries() (entries []*addEntry, err error) {
+	if paths := o.Data["torrents"]; paths != "" {
+		for _, filePath := range strings.Split(paths, "\n") {
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				return nil, err
+			}
+
+			entry := &addEntry{path: filePath, result: AddResult{Source: filePath}}
+			entry.parse(data)
+			entries = append(entries, entry)
+		}
+	}
+
+	for _, source := range o.sources {
+		if source.reader != nil {
+			source.data, err = io.ReadAll(source.reader)
+			if err != nil {
+				return
+			}
+			source.reader = nil
+		}
+
+		entry := &addEntry{source: &source, result: AddResult{Source: source.name}}
+		entry.parse(source.data)
+		entries = append(entries, entry)
+	}
+
+	if urls := o.Data["urls"]; urls != "" {
+		for _, link := range strings.Split(urls, "\n") {
+			entry := &addEntry{url: link, result: AddResult{Source: link}}
+			if magnet, err := ParseMagnet(link); err == nil {
+				entry.result.Hash, entry.result.Name = magnet.Hash(), magnet.Name
+			}
+			entry.magnet = strings.HasPrefix(link, "magnet:")
+			entries = append(entries, entry)
+		}
+	}
+
+	return
+}
+
+// parse gets the hash and name of a .torrent file, a torrent that can't be parsed is still sent to qBittorrent
+func (e *addEntry) parse(data []byte) {
+	meta, err := metainfo.Parse(data)
+	if err != nil {
+		return
+	}
+
+	e.meta = meta
+	e.result.Hash = meta.Hash()
+	e.result.Name = meta.Info.Name
+}
+
+// markDuplicates marks the torrents already in qBittorrent, or given more than once
+func (c *Client) markDuplicates(ctx context.Context, entries []*addEntry) (err error) {
+	var hashes []string
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		hash := entry.result.Hash
+		if hash == "" {
+			continue
+		}
+		if seen[hash] {
+			entry.result.Duplicate = true
+			continue
+		}
+		seen[hash] = true
+		hashes = append(hashes, hash)
+	}
+
+	if len(hashes) == 0 {
+		return
+	}
+
+	torrents, err := c.GetTorrentListCtx(ctx, &GetTorrentListOptions{Hashes: hashes})
+	if err != nil {
+		return
+	}
+
+	existing := make(map[string]string, len(torrents))
+	for _, torrent := range torrents {
+		existing[strings.ToLower(torrent.Hash)] = torrent.Name
+	}
+
+	for _, entry := range entries {
+		if name, ok := existing[entry.result.Hash]; ok {
+			entry.result.Duplicate = true
+			entry.result.Name = name
+		}
+	}
+
+	return
+}
+
+// waitAdded polls the torrent list until the new torrents are in it, and have their metadata with opts.WaitMetadata
+func (c *Client) waitAdded(ctx context.Context, entries []*addEntry, opts *AddTorrentsOptions) (err error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	timeout := opts.WaitTimeout
+	if timeout <= 0 {
+		timeout = defaultAddWaitTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pending := map[string]*addEntry{}
+	for _, entry := range entries {
+		if !entry.result.Duplicate && entry.result.Hash != "" {
+			pending[entry.result.Hash] = entry
+		}
+	}
+
+	for {
+		hashes := make([]string, 0, len(pending))
+		for hash := range pending {
+			hashes = append(hashes, hash)
+		}
+		if len(hashes) == 0 {
+			return nil
+		}
+
+		torrents, err := c.GetTorrentListCtx(ctx, &GetTorrentListOptions{Hashes: hashes})
+		if err != nil {
+			return err
+		}
+
+		for _, torrent := range torrents {
+			entry, ok := pending[strings.ToLower(torrent.Hash)]
+			if !ok {
+				continue
+			}
+			entry.result.Name = torrent.Name
+
+			// a magnet link without metadata is not always in the metaDL state, e.g. when it's queued or stopped,
+			// its size is only known once the metadata is received
+			if opts.WaitMetadata && entry.magnet && torrent.TotalSize <= 0 {
+				continue
+			}
+			delete(pending, strings.ToLower(torrent.Hash))
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("qbittorrent: waiting for the added torrents, %d missing: %w", len(pending), ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
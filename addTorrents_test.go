@@ -0,0 +1,153 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+)
+
+const ubuntuMagnet = "magnet:?xt=urn:btih:" + ubuntuHash + "&dn=ubuntu"
+
+func TestAddTorrents(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	debian := testTorrent(t, "debian", 1<<10)
+	meta, err := metainfo.Parse(debian)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "debian.torrent")
+	err = os.WriteFile(path, debian, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	torrent := qbittorrent.NewTorrent().
+		AddFromFile(path).
+		AddFromBytes("copy.torrent", debian).
+		AddUrl(ubuntuMagnet).
+		AddUrl("https://example.com/arch.torrent")
+
+	results, err := client.AddTorrents(context.Background(), torrent, &qbittorrent.AddTorrentsOptions{WaitAdded: true, PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []qbittorrent.AddResult{
+		{Source: path, Hash: meta.Hash(), Name: "debian"},
+		{Source: "copy.torrent", Hash: meta.Hash(), Name: "debian", Duplicate: true},
+		{Source: ubuntuMagnet, Hash: ubuntuHash, Name: "ubuntu"},
+		{Source: "https://example.com/arch.torrent"},
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("result %d: got %+v, want %+v", i, results[i], want[i])
+		}
+	}
+	if torrents := srv.Torrents(); len(torrents) != 3 {
+		t.Fatalf("got %d torrents, want 3", len(torrents))
+	}
+
+	// the torrents are in qBittorrent now, nothing is sent
+	adds := srv.Requests(addEndpoint)
+	results, err = client.AddTorrents(context.Background(), qbittorrent.NewTorrent().AddFromBytes("debian.torrent", debian).AddUrl(ubuntuMagnet), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Duplicate || !results[1].Duplicate {
+		t.Fatalf("got %+v, want duplicates", results)
+	}
+	if srv.Requests(addEndpoint) != adds {
+		t.Fatal("duplicates were sent")
+	}
+}
+
+func TestAddTorrentsWaitMetadata(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	go func() {
+		for {
+			if _, ok := srv.Torrent(ubuntuHash); ok {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		// queued before it received its metadata, the state alone doesn't tell it's missing
+		srv.UpdateTorrent(ubuntuHash, func(info *qbittorrent.TorrentListResponse) { info.State = qbittorrent.TorrentStateQueuedDL })
+		time.Sleep(50 * time.Millisecond)
+
+		srv.SetTorrentFiles(ubuntuHash, []qbittorrent.TorrentFile{{Name: "ubuntu.iso", Size: 1 << 20}})
+		srv.UpdateTorrent(ubuntuHash, func(info *qbittorrent.TorrentListResponse) { info.State = qbittorrent.TorrentStateStalledDL })
+	}()
+
+	start := time.Now()
+	_, err := client.AddTorrents(context.Background(), qbittorrent.NewTorrent().AddUrl(ubuntuMagnet),
+		&qbittorrent.AddTorrentsOptions{WaitMetadata: true, PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("returned after %v, before the metadata was received", elapsed)
+	}
+}
+
+func TestAddTorrentsWaitTimeout(t *testing.T) {
+	_, client := newTestClient(t)
+
+	// the magnet link never receives its metadata
+	_, err := client.AddTorrents(context.Background(), qbittorrent.NewTorrent().AddUrl(ubuntuMagnet),
+		&qbittorrent.AddTorrentsOptions{WaitMetadata: true, PollInterval: 10 * time.Millisecond, WaitTimeout: 50 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestAddTorrentsFails(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	// qBittorrent answers 200 "Fails." when it adds none of the torrents
+	srv.FailNext(addEndpoint, 2, http.StatusOK, "Fails.")
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.AddTorrents(context.Background(), qbittorrent.NewTorrent().AddUrl(ubuntuMagnet), &qbittorrent.AddTorrentsOptions{WaitAdded: true})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, qbittorrent.ErrAddFailed) {
+			t.Fatalf("got error %v, want ErrAddFailed", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("AddTorrents waited for a torrent that was not added")
+	}
+
+	err := client.AddNewTorrentWithOptions(qbittorrent.NewTorrent().AddFromBytes("debian.torrent", testTorrent(t, "debian", 1<<10)))
+	if !errors.Is(err, qbittorrent.ErrAddFailed) {
+		t.Fatalf("multipart request: got error %v, want ErrAddFailed", err)
+	}
+}
+
+func TestAddNewTorrentDuplicate(t *testing.T) {
+	_, client := newTestClient(t)
+
+	err := client.AddNewTorrent(qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.AddNewTorrent(qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).Data)
+	if !errors.Is(err, qbittorrent.ErrAddFailed) {
+		t.Fatalf("got error %v, want ErrAddFailed", err)
+	}
+}
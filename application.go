@@ -1,6 +1,7 @@
 package qbittorrent
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 )
@@ -14,7 +15,12 @@ The response is a string with the application version, e.g. v4.1.3
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-application-version
 */
 func (c *Client) GetApplicationVersion() (version string, err error) {
-	body, err := c.getReq("/api/v2/app/version", nil)
+	return c.GetApplicationVersionCtx(context.Background())
+}
+
+// GetApplicationVersionCtx is like [Client.GetApplicationVersion] but uses ctx for the underlying requests.
+func (c *Client) GetApplicationVersionCtx(ctx context.Context) (version string, err error) {
+	body, err := c.getReq(ctx, "/api/v2/app/version", nil)
 	if err != nil {
 		return
 	}
@@ -33,7 +39,12 @@ The response is a string with the WebAPI version, e.g. 2.0
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-api-version
 */
 func (c *Client) GetAPIVersion() (version string, err error) {
-	body, err := c.getReq("/api/v2/app/webapiVersion", nil)
+	return c.GetAPIVersionCtx(context.Background())
+}
+
+// GetAPIVersionCtx is like [Client.GetAPIVersion] but uses ctx for the underlying requests.
+func (c *Client) GetAPIVersionCtx(ctx context.Context) (version string, err error) {
+	body, err := c.getReq(ctx, "/api/v2/app/webapiVersion", nil)
 	if err != nil {
 		return
 	}
@@ -52,7 +63,12 @@ The response is a [BuildInfo] struct
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-build-info
 */
 func (c *Client) GetBuildInfo() (info BuildInfo, err error) {
-	body, err := c.getReq("/api/v2/app/buildInfo", nil)
+	return c.GetBuildInfoCtx(context.Background())
+}
+
+// GetBuildInfoCtx is like [Client.GetBuildInfo] but uses ctx for the underlying requests.
+func (c *Client) GetBuildInfoCtx(ctx context.Context) (info BuildInfo, err error) {
+	body, err := c.getReq(ctx, "/api/v2/app/buildInfo", nil)
 	if err != nil {
 		return
 	}
@@ -69,7 +85,12 @@ func (c *Client) GetBuildInfo() (info BuildInfo, err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#shutdown-application
 */
 func (c *Client) ShutdownApplication() (err error) {
-	_, err = c.postReq("/api/v2/app/shutdown", nil)
+	return c.ShutdownApplicationCtx(context.Background())
+}
+
+// ShutdownApplicationCtx is like [Client.ShutdownApplication] but uses ctx for the underlying requests.
+func (c *Client) ShutdownApplicationCtx(ctx context.Context) (err error) {
+	_, err = c.postReq(ctx, "/api/v2/app/shutdown", nil)
 	if err != nil {
 		return
 	}
@@ -85,7 +106,12 @@ Returns the application's settings. The contents may vary depending on which set
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-application-preferences
 */
 func (c *Client) GetApplicationPreferences() (results ApplicationPreferences, err error) {
-	body, err := c.getReq("/api/v2/app/preferences", nil)
+	return c.GetApplicationPreferencesCtx(context.Background())
+}
+
+// GetApplicationPreferencesCtx is like [Client.GetApplicationPreferences] but uses ctx for the underlying requests.
+func (c *Client) GetApplicationPreferencesCtx(ctx context.Context) (results ApplicationPreferences, err error) {
+	body, err := c.getReq(ctx, "/api/v2/app/preferences", nil)
 	if err != nil {
 		return
 	}
@@ -116,6 +142,11 @@ func (c *Client) GetApplicationPreferences() (results ApplicationPreferences, er
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-application-preferences
 */
 func (c *Client) SetApplicationPreferences(prefs map[string]interface{}) (err error) {
+	return c.SetApplicationPreferencesCtx(context.Background(), prefs)
+}
+
+// SetApplicationPreferencesCtx is like [Client.SetApplicationPreferences] but uses ctx for the underlying requests.
+func (c *Client) SetApplicationPreferencesCtx(ctx context.Context, prefs map[string]interface{}) (err error) {
 	jsonPrefs, err := json.Marshal(prefs)
 	if err != nil {
 		return
@@ -124,7 +155,7 @@ func (c *Client) SetApplicationPreferences(prefs map[string]interface{}) (err er
 	params := url.Values{}
 	params.Add("json", string(jsonPrefs))
 
-	_, err = c.postReq("/api/v2/app/setPreferences", &params)
+	_, err = c.postReq(ctx, "/api/v2/app/setPreferences", &params)
 	if err != nil {
 		return
 	}
@@ -141,7 +172,12 @@ The response is a string with the default save path, e.g. C:/Users/Dayman/Downlo
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-default-save-path
 */
 func (c *Client) GetDefaultSavePath() (path string, err error) {
-	body, err := c.getReq("/api/v2/app/defaultSavePath", nil)
+	return c.GetDefaultSavePathCtx(context.Background())
+}
+
+// GetDefaultSavePathCtx is like [Client.GetDefaultSavePath] but uses ctx for the underlying requests.
+func (c *Client) GetDefaultSavePathCtx(ctx context.Context) (path string, err error) {
+	body, err := c.getReq(ctx, "/api/v2/app/defaultSavePath", nil)
 	if err != nil {
 		return
 	}
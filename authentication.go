@@ -1,10 +1,14 @@
 package qbittorrent
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 /*
@@ -13,65 +17,220 @@ import (
   - "password" Password used to access the WebUI
 
 # Http Error Codes
-  - 403 User's IP is banned for too many failed login attempts
+  - 403 User's IP is banned for too many failed login attempts, the returned error matches [ErrBanned]
+
+Wrong credentials are reported as [ErrLoginFailed].
 
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#login
 */
 func (c *Client) Login(username, password string) (err error) {
-	query, err := url.JoinPath(c.ServerURL, "/api/v2/auth/login")
+	return c.LoginCtx(context.Background(), username, password)
+}
+
+// LoginCtx is like [Client.Login] but uses ctx for the underlying requests.
+func (c *Client) LoginCtx(ctx context.Context, username, password string) (err error) {
+	return c.LoginWithCtx(ctx, StaticCredentials(username, password))
+}
+
+/*
+LoginWith is like [Client.Login] but asks provider for the credentials, now and every time the session has to be renewed.
+
+# Example
+
+	err := client.LoginWith(qbittorrent.FileCredentials("/run/secrets/qbt_user", "/run/secrets/qbt_pass"))
+*/
+func (c *Client) LoginWith(provider CredentialProvider) (err error) {
+	return c.LoginWithCtx(context.Background(), provider)
+}
+
+// LoginWithCtx is like [Client.LoginWith] but uses ctx for the underlying requests.
+func (c *Client) LoginWithCtx(ctx context.Context, provider CredentialProvider) (err error) {
+	if c.optionsErr != nil {
+		return c.optionsErr
+	}
+	if c.authBypass {
+		return
+	}
+
+	if c.sessions != nil {
+		restored, err := c.restoreSession(ctx)
+		if err != nil {
+			return err
+		}
+		if restored {
+			c.setCredentials(provider)
+			return nil
+		}
+	}
+
+	return c.login(ctx, provider)
+}
+
+// login requests a new session and saves it in the session store
+func (c *Client) login(ctx context.Context, provider CredentialProvider) (err error) {
+	credentials, err := provider.Credentials(ctx)
 	if err != nil {
 		return
 	}
 
-	data := fmt.Sprintf("username=%s&password=%s", username, password)
-	req, err := http.NewRequest("POST", query, bytes.NewBuffer([]byte(data)))
+	query, err := url.JoinPath(c.ServerURL, loginEndpoint)
+	if err != nil {
+		return
+	}
+
+	form := url.Values{}
+	form.Set("username", credentials.Username)
+	form.Set("password", credentials.Password)
+
+	req, err := c.newRequest(ctx, "POST", query, strings.NewReader(form.Encode()))
 	if err != nil {
 		return
 	}
 
 	req.Header.Set("Referer", c.ServerURL)
-	req.Header.Set("User-Agent", "qbittorrent-webapi-go v1.0")
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := c.http.Do(req)
 	if err != nil {
 		return
 	}
 	defer res.Body.Close()
 
-	// 403 User's IP is banned for too many failed login attempts
-	if res.StatusCode == 403 {
-		return fmt.Errorf("login failed: %s", res.Status)
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return
 	}
 
+	// 403 User's IP is banned for too many failed login attempts
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("login failed: %s", res.Status)
+		return newAPIError(req, res, loginEndpoint, body)
 	}
 
-	if cookies := res.Cookies(); len(cookies) > 0 {
-		cookieURL, _ := url.Parse(c.ServerURL)
-		c.Jar.SetCookies(cookieURL, cookies)
+	// qBittorrent answers wrong credentials with 200 "Fails."
+	if strings.TrimSpace(string(body)) == "Fails." {
+		return ErrLoginFailed
 	}
 
-	c.http = &http.Client{Jar: c.Jar}
+	c.mu.Lock()
+	c.sessionExpires = time.Time{}
+	for _, cookie := range res.Cookies() {
+		if isSessionCookie(cookie.Name) {
+			c.sessionExpires = cookieExpires(cookie)
+		}
+	}
+	c.mu.Unlock()
 
-	c.username = username
-	c.password = password
+	c.setCredentials(provider)
 
-	return
+	// the client is logged in even if the next one can't reuse the session
+	if c.sessions != nil {
+		if err := c.saveSession(ctx); err != nil {
+			c.sessionError(fmt.Errorf("qbittorrent: saving the session: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// setCredentials stores the provider used to log in again, and starts a new session
+func (c *Client) setCredentials(provider CredentialProvider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.credentials = provider
+	c.session++
 }
 
 /*
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#logout
 */
 func (c *Client) Logout() (err error) {
-	_, err = c.postReq("/api/v2/auth/logout", nil)
+	return c.LogoutCtx(context.Background())
+}
+
+// LogoutCtx is like [Client.Logout] but uses ctx for the underlying requests.
+func (c *Client) LogoutCtx(ctx context.Context) (err error) {
+	_, err = c.postReq(ctx, "/api/v2/auth/logout", nil)
 	if err != nil {
 		return
 	}
 
-	c.username = ""
-	c.password = ""
+	c.mu.Lock()
+	c.credentials = nil
+	c.sessionExpires = time.Time{}
+	c.mu.Unlock()
+
+	if c.sessions != nil {
+		err = c.sessions.Delete(ctx)
+	}
 
 	return
 }
+
+// loginCall is a login shared by the requests that found the session expired
+type loginCall struct {
+	done chan struct{} // closed when the login is done
+	err  error
+}
+
+// currentSession returns the number of successful logins, used to tell whether a 403 happened before or after a login
+func (c *Client) currentSession() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.session
+}
+
+func (c *Client) hasCredentials() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.credentials != nil && !c.authBypass
+}
+
+/*
+relogin logs in again after a request sent during session was rejected with 403.
+
+Concurrent calls share a single login, and no login is done when another one succeeded since session.
+When the shared login fails because the ctx of the goroutine running it is done, the others try again with their own ctx.
+*/
+func (c *Client) relogin(ctx context.Context, session uint64) (err error) {
+	for {
+		c.mu.Lock()
+		if c.session != session {
+			c.mu.Unlock()
+			return nil
+		}
+
+		call := c.loginCall
+		if call == nil {
+			call = &loginCall{done: make(chan struct{})}
+			c.loginCall = call
+			provider := c.credentials
+			c.mu.Unlock()
+
+			call.err = c.login(ctx, provider)
+
+			c.mu.Lock()
+			c.loginCall = nil
+			c.mu.Unlock()
+			close(call.done)
+
+			return call.err
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-call.done:
+		}
+
+		if call.err == nil {
+			return nil
+		}
+		if !errors.Is(call.err, context.Canceled) && !errors.Is(call.err, context.DeadlineExceeded) {
+			return call.err
+		}
+	}
+}
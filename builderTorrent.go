@@ -1,12 +1,32 @@
 package qbittorrent
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 type NewTorrentOptions struct {
-	Data map[string]string
+	Data    map[string]string
+	sources []torrentSource // torrents added with AddFromBytes and AddFromReader, only sent by AddNewTorrentWithOptions
+}
+
+// sourcesParam is set in Data to the number of torrents added with AddFromBytes and AddFromReader, so passing
+// Data alone to AddNewTorrent fails instead of dropping them. It's never sent to qBittorrent
+const sourcesParam = "qbittorrent-webapi-go:sources"
+
+// torrentSource is a .torrent file that is not on the file system
+type torrentSource struct {
+	name   string
+	data   []byte
+	reader io.Reader
 }
 
 func NewTorrent() *NewTorrentOptions {
@@ -26,6 +46,11 @@ func (o *NewTorrentOptions) AddUrl(uri string) *NewTorrentOptions {
 	return o
 }
 
+// Add a torrent from a magnet link, same as AddUrl(magnet.String())
+func (o *NewTorrentOptions) AddMagnet(magnet *Magnet) *NewTorrentOptions {
+	return o.AddUrl(magnet.String())
+}
+
 // Download folder
 func (o *NewTorrentOptions) SavePath(path string) *NewTorrentOptions {
 	o.Data["savepath"] = path
@@ -56,13 +81,21 @@ func (o *NewTorrentOptions) SkipChecking(v bool) *NewTorrentOptions {
 	return o
 }
 
-// Add torrents in the paused state.
-func (o *NewTorrentOptions) Paused(v bool) *NewTorrentOptions {
+// Add torrents in the stopped state. Sets both "stopped" (qBittorrent 5.0) and "paused" (older versions)
+func (o *NewTorrentOptions) Stopped(v bool) *NewTorrentOptions {
+	o.Data["stopped"] = strconv.FormatBool(v)
 	o.Data["paused"] = strconv.FormatBool(v)
 	return o
 }
 
-// Create the root folder.
+// Add torrents in the paused state.
+//
+// Deprecated: qBittorrent 5.0 renamed it, use [NewTorrentOptions.Stopped].
+func (o *NewTorrentOptions) Paused(v bool) *NewTorrentOptions {
+	return o.Stopped(v)
+}
+
+// Create the root folder. Replaced by [NewTorrentOptions.ContentLayout] since WebAPI v2.7.0, it's translated for newer servers
 func (o *NewTorrentOptions) RootFolder(v bool) *NewTorrentOptions {
 	o.Data["root_folder"] = strconv.FormatBool(v)
 	return o
@@ -105,17 +138,85 @@ func (o *NewTorrentOptions) AutoTMM(v bool) *NewTorrentOptions {
 }
 
 // Enable sequential download.
-func (o *NewTorrentOptions) SequentialDownload(v string) *NewTorrentOptions {
-	o.Data["sequentialDownload"] = v
+func (o *NewTorrentOptions) SequentialDownload(v bool) *NewTorrentOptions {
+	o.Data["sequentialDownload"] = strconv.FormatBool(v)
 	return o
 }
 
 // Prioritize download first last piece
-func (o *NewTorrentOptions) FirstLastPiecePrio(v string) *NewTorrentOptions {
-	o.Data["firstLastPiecePrio"] = v
+func (o *NewTorrentOptions) FirstLastPiecePrio(v bool) *NewTorrentOptions {
+	o.Data["firstLastPiecePrio"] = strconv.FormatBool(v)
+	return o
+}
+
+// Folder for the incomplete torrent, the files are moved to the save path when it's completed. Also enables useDownloadPath
+func (o *NewTorrentOptions) DownloadPath(path string) *NewTorrentOptions {
+	o.Data["downloadPath"] = path
+	o.Data["useDownloadPath"] = "true"
+	return o
+}
+
+// Whether the incomplete torrent is kept in the download path, see [NewTorrentOptions.DownloadPath]
+func (o *NewTorrentOptions) UseDownloadPath(v bool) *NewTorrentOptions {
+	o.Data["useDownloadPath"] = strconv.FormatBool(v)
+	return o
+}
+
+// Layout of the torrent content, see [ContentLayout]. Translated to root_folder for servers older than WebAPI v2.7.0
+func (o *NewTorrentOptions) ContentLayout(v ContentLayout) *NewTorrentOptions {
+	o.Data["contentLayout"] = string(v)
+	return o
+}
+
+// Stop the torrent when the condition is met, see [StopCondition]. Requires WebAPI v2.8.14 or newer
+func (o *NewTorrentOptions) StopCondition(v StopCondition) *NewTorrentOptions {
+	o.Data["stopCondition"] = string(v)
+	return o
+}
+
+// Set torrent inactive seeding time limit. Unit in minutes
+func (o *NewTorrentOptions) InactiveSeedingTimeLimit(v int) *NewTorrentOptions {
+	o.Data["inactiveSeedingTimeLimit"] = strconv.Itoa(v)
+	return o
+}
+
+// What to do when the share limits are reached, see [ShareLimitAction]
+func (o *NewTorrentOptions) ShareLimitAction(v ShareLimitAction) *NewTorrentOptions {
+	o.Data["shareLimitAction"] = string(v)
+	return o
+}
+
+// Add the torrent to the top of the queue
+func (o *NewTorrentOptions) AddToTopOfQueue(v bool) *NewTorrentOptions {
+	o.Data["addToTopOfQueue"] = strconv.FormatBool(v)
+	return o
+}
+
+// Force start the torrent, ignoring the queue limits
+func (o *NewTorrentOptions) Forced(v bool) *NewTorrentOptions {
+	o.Data["forced"] = strconv.FormatBool(v)
+	return o
+}
+
+// SSL certificate (PEM) used to connect to SSL torrents
+func (o *NewTorrentOptions) SSLCertificate(pem string) *NewTorrentOptions {
+	o.Data["ssl_certificate"] = pem
+	return o
+}
+
+// SSL private key (PEM) of the certificate, see [NewTorrentOptions.SSLCertificate]
+func (o *NewTorrentOptions) SSLPrivateKey(pem string) *NewTorrentOptions {
+	o.Data["ssl_private_key"] = pem
+	return o
+}
+
+// SSL Diffie-Hellman parameters (PEM), see [NewTorrentOptions.SSLCertificate]
+func (o *NewTorrentOptions) SSLDHParams(pem string) *NewTorrentOptions {
+	o.Data["ssl_dh_params"] = pem
 	return o
 }
 
+// Add a torrent from a .torrent file on the file system, it's read when the torrent is added
 func (o *NewTorrentOptions) AddFromFile(filePath string) *NewTorrentOptions {
 	if path, ok := o.Data["torrents"]; ok {
 		o.Data["torrents"] = path + "\n" + filePath
@@ -125,3 +226,191 @@ func (o *NewTorrentOptions) AddFromFile(filePath string) *NewTorrentOptions {
 
 	return o
 }
+
+// Add a torrent from the content of a .torrent file, name is the file name sent to qBittorrent.
+// Only sent by [Client.AddNewTorrentWithOptions], as it's not part of Data: [Client.AddNewTorrent] fails with
+// [ErrInvalidOptions] when given the Data of these options
+func (o *NewTorrentOptions) AddFromBytes(name string, data []byte) *NewTorrentOptions {
+	o.sources = append(o.sources, torrentSource{name: name, data: data})
+	o.Data[sourcesParam] = strconv.Itoa(len(o.sources))
+	return o
+}
+
+// Add a torrent from a reader of a .torrent file, name is the file name sent to qBittorrent.
+// The reader is read once, so the torrent can't be sent again when the request is retried or the session has
+// expired, use [NewTorrentOptions.AddFromBytes] if that matters.
+// Only sent by [Client.AddNewTorrentWithOptions], as it's not part of Data: [Client.AddNewTorrent] fails with
+// [ErrInvalidOptions] when given the Data of these options
+func (o *NewTorrentOptions) AddFromReader(name string, r io.Reader) *NewTorrentOptions {
+	o.sources = append(o.sources, torrentSource{name: name, reader: r})
+	o.Data[sourcesParam] = strconv.Itoa(len(o.sources))
+	return o
+}
+
+/*
+Validate checks the options for missing or incompatible parameters, it's run by [Client.AddNewTorrent].
+
+The returned error matches [ErrInvalidOptions]. Parameters depending on the server version are checked when
+the torrent is added, see [Client.Capabilities].
+*/
+func (o *NewTorrentOptions) Validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidOptions, fmt.Sprintf(format, args...))
+	}
+
+	if n, ok := o.Data[sourcesParam]; ok && n != strconv.Itoa(len(o.sources)) {
+		return invalid("the torrents added with AddFromBytes or AddFromReader are not part of Data, use AddNewTorrentWithOptions")
+	}
+
+	if o.Data["urls"] == "" && o.Data["torrents"] == "" && len(o.sources) == 0 {
+		return invalid("no URL or file to add")
+	}
+
+	if _, ok := o.Data["root_folder"]; ok {
+		if _, ok := o.Data["contentLayout"]; ok {
+			return invalid("root_folder and contentLayout can't be used together")
+		}
+	}
+
+	if v, ok := o.Data["contentLayout"]; ok {
+		switch ContentLayout(v) {
+		case ContentLayoutOriginal, ContentLayoutSubfolder, ContentLayoutNoSubfolder:
+		default:
+			return invalid("unknown contentLayout %q", v)
+		}
+	}
+
+	if v, ok := o.Data["stopCondition"]; ok {
+		switch StopCondition(v) {
+		case StopConditionNone, StopConditionMetadataReceived, StopConditionFilesChecked:
+		default:
+			return invalid("unknown stopCondition %q", v)
+		}
+	}
+
+	if v, ok := o.Data["shareLimitAction"]; ok {
+		switch ShareLimitAction(v) {
+		case ShareLimitActionDefault, ShareLimitActionStop, ShareLimitActionRemove, ShareLimitActionRemoveWithContent, ShareLimitActionEnableSuperSeeding:
+		default:
+			return invalid("unknown shareLimitAction %q", v)
+		}
+	}
+
+	if o.Data["downloadPath"] != "" && o.Data["useDownloadPath"] == "false" {
+		return invalid("downloadPath is set but useDownloadPath is false")
+	}
+
+	if (o.Data["ssl_certificate"] == "") != (o.Data["ssl_private_key"] == "") {
+		return invalid("ssl_certificate and ssl_private_key must be set together")
+	}
+
+	return nil
+}
+
+// adaptNewTorrent translates the add parameters that changed between server versions, formData is not modified
+func (c *Client) adaptNewTorrent(ctx context.Context, formData map[string]string) (map[string]string, error) {
+	rootFolder, hasRootFolder := formData["root_folder"]
+	contentLayout, hasContentLayout := formData["contentLayout"]
+	stopCondition, hasStopCondition := formData["stopCondition"]
+
+	adapted := maps.Clone(formData)
+	delete(adapted, sourcesParam)
+	if !hasRootFolder && !hasContentLayout && !hasStopCondition {
+		return adapted, nil
+	}
+
+	caps, err := c.CapabilitiesCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasStopCondition && !caps.StopCondition && StopCondition(stopCondition) != StopConditionNone {
+		return nil, fmt.Errorf("%w: stopCondition requires WebAPI %s, the server has %s", ErrUnsupported, apiVersionStopCondition, caps.APIVersion)
+	}
+
+	switch {
+	case hasRootFolder && caps.ContentLayout:
+		delete(adapted, "root_folder")
+		adapted["contentLayout"] = string(ContentLayoutNoSubfolder)
+		if rootFolder == "true" {
+			adapted["contentLayout"] = string(ContentLayoutSubfolder)
+		}
+	case hasContentLayout && !caps.ContentLayout:
+		delete(adapted, "contentLayout")
+		switch ContentLayout(contentLayout) {
+		case ContentLayoutSubfolder:
+			adapted["root_folder"] = "true"
+		case ContentLayoutNoSubfolder:
+			adapted["root_folder"] = "false"
+		}
+	}
+
+	return adapted, nil
+}
+
+// torrentFiles returns the .torrent files to upload, the files added by path are only opened when the request is sent
+func (o *NewTorrentOptions) torrentFiles() (files []multipartFile, err error) {
+	if paths := o.Data["torrents"]; paths != "" {
+		for _, filePath := range strings.Split(paths, "\n") {
+			info, err := os.Stat(filePath)
+			if err != nil {
+				return nil, err
+			}
+
+			files = append(files, multipartFile{
+				field: "torrents",
+				name:  filepath.Base(filePath),
+				size:  info.Size(),
+				open:  func() (io.ReadCloser, error) { return os.Open(filePath) },
+			})
+		}
+	}
+
+	for _, source := range o.sources {
+		file, err := source.multipartFile()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return
+}
+
+func (s torrentSource) multipartFile() (file multipartFile, err error) {
+	file = multipartFile{field: "torrents", name: s.name}
+
+	data := s.data
+	if s.reader != nil {
+		size := int64(-1)
+		switch r := s.reader.(type) {
+		case interface{ Len() int }:
+			size = int64(r.Len())
+		case *os.File:
+			if info, err := r.Stat(); err == nil && info.Mode().IsRegular() {
+				size = info.Size()
+			}
+		}
+
+		if size < 0 {
+			// the size is needed to send the request, only this torrent is loaded in memory
+			data, err = io.ReadAll(s.reader)
+			if err != nil {
+				return
+			}
+		} else {
+			var once sync.Once
+			file.size = size
+			file.open = func() (reader io.ReadCloser, err error) {
+				err = fmt.Errorf("qbittorrent: %s was already read, a torrent added with AddFromReader can't be sent again", s.name)
+				once.Do(func() { reader, err = io.NopCloser(s.reader), nil })
+				return
+			}
+			return
+		}
+	}
+
+	file.size = int64(len(data))
+	file.open = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(data)), nil }
+	return
+}
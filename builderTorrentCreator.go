@@ -0,0 +1,83 @@
+package qbittorrent
+
+import (
+	"strconv"
+	"strings"
+)
+
+type TorrentCreatorOptions struct {
+	Data map[string]string
+}
+
+// NewTorrentCreatorTask returns the options of a torrent creation task for the file or folder at sourcePath on the server.
+func NewTorrentCreatorTask(sourcePath string) *TorrentCreatorOptions {
+	return &TorrentCreatorOptions{
+		Data: map[string]string{"sourcePath": sourcePath},
+	}
+}
+
+// Save the .torrent file at this path on the server, it's only kept in memory by default
+func (o *TorrentCreatorOptions) TorrentFilePath(path string) *TorrentCreatorOptions {
+	o.Data["torrentFilePath"] = path
+	return o
+}
+
+// Torrent format, see [TorrentFormat]. Defaults to hybrid
+func (o *TorrentCreatorOptions) Format(v TorrentFormat) *TorrentCreatorOptions {
+	o.Data["format"] = string(v)
+	return o
+}
+
+// Piece size in bytes, a power of 2 from 16 KiB. Defaults to 0 for automatic
+func (o *TorrentCreatorOptions) PieceSize(v int) *TorrentCreatorOptions {
+	o.Data["pieceSize"] = strconv.Itoa(v)
+	return o
+}
+
+// Optimize the file alignment, only used by the v1 format
+func (o *TorrentCreatorOptions) OptimizeAlignment(v bool) *TorrentCreatorOptions {
+	o.Data["optimizeAlignment"] = strconv.FormatBool(v)
+	return o
+}
+
+// Don't align files smaller than this size in bytes, -1 for no limit
+func (o *TorrentCreatorOptions) PaddedFileSizeLimit(v int) *TorrentCreatorOptions {
+	o.Data["paddedFileSizeLimit"] = strconv.Itoa(v)
+	return o
+}
+
+// Private torrent, only the given trackers are used to find peers
+func (o *TorrentCreatorOptions) Private(v bool) *TorrentCreatorOptions {
+	o.Data["private"] = strconv.FormatBool(v)
+	return o
+}
+
+// Tracker URLs
+func (o *TorrentCreatorOptions) Trackers(v []string) *TorrentCreatorOptions {
+	o.Data["trackers"] = strings.Join(v, "|")
+	return o
+}
+
+// Web seed URLs
+func (o *TorrentCreatorOptions) WebSeeds(v []string) *TorrentCreatorOptions {
+	o.Data["urlSeeds"] = strings.Join(v, "|")
+	return o
+}
+
+// Torrent comment
+func (o *TorrentCreatorOptions) Comment(v string) *TorrentCreatorOptions {
+	o.Data["comment"] = v
+	return o
+}
+
+// Torrent source field, used by private trackers to make the info hash unique
+func (o *TorrentCreatorOptions) Source(v string) *TorrentCreatorOptions {
+	o.Data["source"] = v
+	return o
+}
+
+// Add the created torrent to qBittorrent and seed it. Defaults to true when no torrent file path is given
+func (o *TorrentCreatorOptions) StartSeeding(v bool) *TorrentCreatorOptions {
+	o.Data["startSeeding"] = strconv.FormatBool(v)
+	return o
+}
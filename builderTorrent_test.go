@@ -0,0 +1,131 @@
+package qbittorrent_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+func TestNewTorrentValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		torrent *qbittorrent.NewTorrentOptions
+		valid   bool
+	}{
+		{"url", qbittorrent.NewTorrent().AddUrl(ubuntuMagnet), true},
+		{"bytes", qbittorrent.NewTorrent().AddFromBytes("ubuntu.torrent", []byte("d4:infode")), true},
+		{"no source", qbittorrent.NewTorrent().SavePath("/downloads"), false},
+		{"root folder", qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).RootFolder(true), true},
+		{"content layout", qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).ContentLayout(qbittorrent.ContentLayoutNoSubfolder), true},
+		{"root folder and content layout", qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).RootFolder(false).ContentLayout(qbittorrent.ContentLayoutOriginal), false},
+		{"unknown content layout", qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).ContentLayout("Flat"), false},
+		{"unknown stop condition", qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).StopCondition("Downloaded"), false},
+		{"unknown share limit action", qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).ShareLimitAction("Pause"), false},
+		{"download path disabled", qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).DownloadPath("/incomplete").UseDownloadPath(false), false},
+		{"certificate without key", qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).SSLCertificate("cert"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.torrent.Validate()
+			if tt.valid && err != nil {
+				t.Fatalf("got error %v, want valid options", err)
+			}
+			if !tt.valid && !errors.Is(err, qbittorrent.ErrInvalidOptions) {
+				t.Fatalf("got error %v, want ErrInvalidOptions", err)
+			}
+		})
+	}
+}
+
+func TestNewTorrentVersionTranslation(t *testing.T) {
+	// absent is the value of a parameter that must not be sent
+	const absent = "<absent>"
+
+	tests := []struct {
+		name          string
+		apiVersion    string
+		torrent       *qbittorrent.NewTorrentOptions
+		rootFolder    string
+		contentLayout string
+	}{
+		{"root folder on a new server", apiVersion4, qbittorrent.NewTorrent().RootFolder(true), absent, "Subfolder"},
+		{"no root folder on a new server", apiVersion4, qbittorrent.NewTorrent().RootFolder(false), absent, "NoSubfolder"},
+		{"content layout on a new server", apiVersion4, qbittorrent.NewTorrent().ContentLayout(qbittorrent.ContentLayoutOriginal), absent, "Original"},
+		{"subfolder on an old server", "2.6.2", qbittorrent.NewTorrent().ContentLayout(qbittorrent.ContentLayoutSubfolder), "true", absent},
+		{"no subfolder on an old server", "2.6.2", qbittorrent.NewTorrent().ContentLayout(qbittorrent.ContentLayoutNoSubfolder), "false", absent},
+		{"original layout on an old server", "2.6.2", qbittorrent.NewTorrent().ContentLayout(qbittorrent.ContentLayoutOriginal), absent, absent},
+		{"root folder on an old server", "2.6.2", qbittorrent.NewTorrent().RootFolder(true), "true", absent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client := newTestClientVersion(t, tt.apiVersion)
+			adds := recordAdds(srv)
+
+			err := client.AddNewTorrent(tt.torrent.AddUrl(ubuntuMagnet).Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := adds()[0]
+			for param, want := range map[string]string{"root_folder": tt.rootFolder, "contentLayout": tt.contentLayout} {
+				got, ok := form[param]
+				if !ok {
+					got = absent
+				}
+				if got != want {
+					t.Fatalf("%s: got %q, want %q", param, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAddNewTorrentSourcesNotInData(t *testing.T) {
+	srv, client := newTestClient(t)
+	adds := recordAdds(srv)
+	data := testTorrent(t, "ubuntu", 1<<10)
+
+	tests := []struct {
+		name    string
+		torrent *qbittorrent.NewTorrentOptions
+	}{
+		{"bytes", qbittorrent.NewTorrent().AddFromBytes("ubuntu.torrent", data)},
+		{"bytes and url", qbittorrent.NewTorrent().AddFromBytes("ubuntu.torrent", data).AddUrl(ubuntuMagnet)},
+		{"reader and url", qbittorrent.NewTorrent().AddFromReader("ubuntu.torrent", bytes.NewReader(data)).AddUrl(ubuntuMagnet)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.AddNewTorrent(tt.torrent.Data)
+			if !errors.Is(err, qbittorrent.ErrInvalidOptions) {
+				t.Fatalf("got error %v, want ErrInvalidOptions", err)
+			}
+		})
+	}
+	if srv.Requests(addEndpoint) != 0 {
+		t.Fatal("the torrents were sent without their files")
+	}
+
+	// the same options are sent in full by AddNewTorrentWithOptions
+	err := client.AddNewTorrentWithOptions(tests[1].torrent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	torrents, err := client.GetTorrentList(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(torrents) != 2 {
+		t.Fatalf("got %d torrents, want the file and the magnet link", len(torrents))
+	}
+	for param := range adds()[0] {
+		if strings.Contains(param, "sources") {
+			t.Fatalf("got internal parameter %q sent to the server", param)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package qbittorrent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Capabilities describes the features of the connected server, see [Client.Capabilities].
+type Capabilities struct {
+	APIVersion     string // WebAPI version of the server (e.g. 2.11.2)
+	StopStart      bool   // Torrents are stopped and started with torrents/stop and torrents/start instead of pause and resume (qBittorrent 5.0)
+	StoppedStates  bool   // Stopped torrents use the stoppedUP/stoppedDL states and the stopped/running filters instead of paused/resumed
+	ExportTorrent  bool   // torrents/export is available
+	TorrentCreator bool   // The torrentcreator endpoints are available
+	WebSeedEditing bool   // torrents/addWebSeeds, editWebSeed and removeWebSeeds are available
+	ContentLayout  bool   // The contentLayout parameter is accepted when adding a torrent
+	StopCondition  bool   // The stopCondition parameter is accepted when adding a torrent
+}
+
+// WebAPI versions introducing the features of [Capabilities]
+const (
+	apiVersionContentLayout  = "2.7.0"
+	apiVersionExportTorrent  = "2.8.14"
+	apiVersionStopCondition  = "2.8.14"
+	apiVersionStopStart      = "2.11.0"
+	apiVersionTorrentCreator = "2.11.2"
+	apiVersionWebSeedEditing = "2.11.3"
+)
+
+func newCapabilities(apiVersion string) *Capabilities {
+	return &Capabilities{
+		APIVersion:     apiVersion,
+		StopStart:      apiVersionAtLeast(apiVersion, apiVersionStopStart),
+		StoppedStates:  apiVersionAtLeast(apiVersion, apiVersionStopStart),
+		ExportTorrent:  apiVersionAtLeast(apiVersion, apiVersionExportTorrent),
+		TorrentCreator: apiVersionAtLeast(apiVersion, apiVersionTorrentCreator),
+		WebSeedEditing: apiVersionAtLeast(apiVersion, apiVersionWebSeedEditing),
+		ContentLayout:  apiVersionAtLeast(apiVersion, apiVersionContentLayout),
+		StopCondition:  apiVersionAtLeast(apiVersion, apiVersionStopCondition),
+	}
+}
+
+// AtLeast reports whether the WebAPI version of the server is at least version (e.g. "2.8.14").
+func (caps Capabilities) AtLeast(version string) bool {
+	return apiVersionAtLeast(caps.APIVersion, version)
+}
+
+/*
+Capabilities returns the features supported by the server, based on its WebAPI version.
+
+The version is requested the first time it's needed and cached until the next login, as the server may have been upgraded.
+Methods like [Client.PauseTorrents] use it to call the right endpoint for the server,
+and methods that need a newer server return an error matching [ErrUnsupported].
+*/
+func (c *Client) Capabilities() (caps Capabilities, err error) {
+	return c.CapabilitiesCtx(context.Background())
+}
+
+// CapabilitiesCtx is like [Client.Capabilities] but uses ctx for the underlying requests.
+func (c *Client) CapabilitiesCtx(ctx context.Context) (caps Capabilities, err error) {
+	c.mu.Lock()
+	cached, cachedSession, session := c.caps, c.capsSession, c.session
+	c.mu.Unlock()
+
+	if cached != nil && cachedSession == session {
+		return *cached, nil
+	}
+
+	version, err := c.GetAPIVersionCtx(ctx)
+	if err != nil {
+		return
+	}
+	cached = newCapabilities(strings.TrimSpace(version))
+
+	c.mu.Lock()
+	c.caps, c.capsSession = cached, c.session
+	c.mu.Unlock()
+
+	return *cached, nil
+}
+
+// requireAPIVersion returns an error matching ErrUnsupported when the server is older than required
+func (c *Client) requireAPIVersion(ctx context.Context, feature, required string) (err error) {
+	caps, err := c.CapabilitiesCtx(ctx)
+	if err != nil {
+		return
+	}
+
+	if !caps.AtLeast(required) {
+		return fmt.Errorf("%w: %s requires WebAPI %s, the server has %s", ErrUnsupported, feature, required, caps.APIVersion)
+	}
+
+	return
+}
+
+// torrentListFilter translates the stopped/running filters to the vocabulary of the server
+func (c *Client) torrentListFilter(ctx context.Context, filter Filters) (Filters, error) {
+	filter = filter.normalize()
+	if filter != FilterStopped && filter != FilterRunning {
+		return filter, nil
+	}
+
+	caps, err := c.CapabilitiesCtx(ctx)
+	if err != nil || caps.StoppedStates {
+		return filter, err
+	}
+
+	if filter == FilterStopped {
+		return FilterPaused, nil
+	}
+	return FilterResumed, nil
+}
+
+// apiVersionAtLeast compares dotted version numbers, a leading "v" is ignored
+func apiVersionAtLeast(version, required string) bool {
+	a := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	b := strings.Split(strings.TrimPrefix(required, "v"), ".")
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x, _ = strconv.Atoi(a[i])
+		}
+		if i < len(b) {
+			y, _ = strconv.Atoi(b[i])
+		}
+		if x != y {
+			return x > y
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,170 @@
+package qbittorrent_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+// WebAPI versions of qBittorrent 4.6 and 5.0
+const (
+	apiVersion4 = "2.9.3"
+	apiVersion5 = "2.11.3"
+)
+
+// recordParam records the values of a parameter sent to an endpoint
+func recordParam(srv *qbittest.Server, endpoint, param string) func() []string {
+	var mu sync.Mutex
+	var values []string
+
+	srv.AddHook(func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == endpoint {
+			mu.Lock()
+			values = append(values, r.FormValue(param))
+			mu.Unlock()
+		}
+		return false
+	})
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return values
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		apiVersion string
+		want       qbittorrent.Capabilities
+	}{
+		{"2.6.2", qbittorrent.Capabilities{}},
+		{"2.8.3", qbittorrent.Capabilities{ContentLayout: true}},
+		{apiVersion4, qbittorrent.Capabilities{ContentLayout: true, StopCondition: true, ExportTorrent: true}},
+		{"2.11.2", qbittorrent.Capabilities{ContentLayout: true, StopCondition: true, ExportTorrent: true, StopStart: true, StoppedStates: true, TorrentCreator: true}},
+		{apiVersion5, qbittorrent.Capabilities{ContentLayout: true, StopCondition: true, ExportTorrent: true, StopStart: true, StoppedStates: true, TorrentCreator: true, WebSeedEditing: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.apiVersion, func(t *testing.T) {
+			srv, client := newTestClientVersion(t, test.apiVersion)
+
+			caps, err := client.Capabilities()
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.want.APIVersion = test.apiVersion
+			if caps != test.want {
+				t.Fatalf("got %+v, want %+v", caps, test.want)
+			}
+
+			// the version is cached until the next login
+			client.Capabilities()
+			if got := srv.Requests("/api/v2/app/webapiVersion"); got != 1 {
+				t.Fatalf("got %d version requests, want 1", got)
+			}
+			login(t, client)
+			client.Capabilities()
+			if got := srv.Requests("/api/v2/app/webapiVersion"); got != 2 {
+				t.Fatalf("got %d version requests after logging in again, want 2", got)
+			}
+		})
+	}
+}
+
+func TestStopStartRouting(t *testing.T) {
+	tests := []struct {
+		apiVersion             string
+		stop, start            string
+		stopped                qbittorrent.TorrentState
+		stoppedFilter, running string
+	}{
+		{apiVersion4, "/api/v2/torrents/pause", "/api/v2/torrents/resume", qbittorrent.TorrentStatePausedDL, "paused", "resumed"},
+		{apiVersion5, "/api/v2/torrents/stop", "/api/v2/torrents/start", qbittorrent.TorrentStateStoppedDL, "stopped", "running"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.apiVersion, func(t *testing.T) {
+			srv, client := newTestClientVersion(t, test.apiVersion)
+			srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: ubuntuHash, Name: "ubuntu", State: qbittorrent.TorrentStateDownloading})
+			srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: debianHash, Name: "debian", State: qbittorrent.TorrentStateDownloading})
+
+			// the deprecated and the new names call the endpoint of the server
+			if err := client.PauseTorrents([]string{ubuntuHash}); err != nil {
+				t.Fatal(err)
+			}
+			if err := client.StopTorrents([]string{debianHash}); err != nil {
+				t.Fatal(err)
+			}
+			if got := srv.Requests(test.stop); got != 2 {
+				t.Fatalf("got %d requests to %s, want 2", got, test.stop)
+			}
+			if state := torrentState(t, srv, ubuntuHash); state != test.stopped || !state.IsStopped() {
+				t.Fatalf("got state %q, want %q", state, test.stopped)
+			}
+
+			if err := client.ResumeTorrents([]string{ubuntuHash}); err != nil {
+				t.Fatal(err)
+			}
+			if got := srv.Requests(test.start); got != 1 {
+				t.Fatalf("got %d requests to %s, want 1", got, test.start)
+			}
+
+			// the filters are translated to the names known by the server
+			filters := recordParam(srv, torrentsEndpoint, "filter")
+			for _, filter := range []qbittorrent.Filters{qbittorrent.FilterStopped, qbittorrent.FilterPaused} {
+				torrents, err := client.GetTorrentList(&qbittorrent.GetTorrentListOptions{Filter: filter})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(torrents) != 1 || torrents[0].Hash != debianHash {
+					t.Fatalf("filter %s: got %+v, want debian", filter, torrents)
+				}
+			}
+			running, err := client.GetTorrentList(&qbittorrent.GetTorrentListOptions{Filter: qbittorrent.FilterRunning})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(running) != 1 || running[0].Hash != ubuntuHash {
+				t.Fatalf("filter running: got %+v, want ubuntu", running)
+			}
+
+			if want := []string{test.stoppedFilter, test.stoppedFilter, test.running}; !reflect.DeepEqual(filters(), want) {
+				t.Fatalf("got filters %v, want %v", filters(), want)
+			}
+		})
+	}
+}
+
+func TestStopConditionUnsupported(t *testing.T) {
+	srv, client := newTestClientVersion(t, "2.8.3")
+
+	torrent := qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).StopCondition(qbittorrent.StopConditionMetadataReceived)
+	err := client.AddNewTorrentWithOptions(torrent)
+	if !errors.Is(err, qbittorrent.ErrUnsupported) {
+		t.Fatalf("got error %v, want ErrUnsupported", err)
+	}
+	if srv.Requests(addEndpoint) != 0 {
+		t.Fatal("the torrent was added without its stop condition")
+	}
+
+	// no stop condition is what an old server does anyway
+	torrent = qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).StopCondition(qbittorrent.StopConditionNone)
+	if err := client.AddNewTorrentWithOptions(torrent); err != nil {
+		t.Fatal(err)
+	}
+
+	srv, client = newTestClientVersion(t, apiVersion4)
+	torrent = qbittorrent.NewTorrent().AddUrl(ubuntuMagnet).StopCondition(qbittorrent.StopConditionMetadataReceived)
+	if err := client.AddNewTorrentWithOptions(torrent); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Torrents()) != 1 {
+		t.Fatal("the torrent was not added")
+	}
+}
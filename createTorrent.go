@@ -0,0 +1,505 @@
+package qbittorrent
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+)
+
+const (
+	blockSize       = 16 << 10 // size of the v2 merkle tree leaves
+	maxAutoPieces   = 2048     // the automatic piece size is the smallest one giving at most this number of pieces
+	maxAutoPieceLen = 16 << 20 // largest automatic piece size
+)
+
+// CreateTorrentOptions are the options of [CreateTorrent].
+type CreateTorrentOptions struct {
+	Format    TorrentFormat // Torrent format, defaults to [TorrentFormatHybrid]
+	PieceSize int64         // Piece size in bytes, a power of two of at least 16 KiB. 0 for automatic
+	Trackers  []string      // Tracker URLs, each one in its own tier
+	WebSeeds  []string      // Web seed URLs
+	Private   bool          // Whether the torrent is private
+	Source    string        // Source tag, used by private trackers
+	Comment   string        // Torrent comment
+	Workers   int           // Number of pieces hashed at the same time, defaults to the number of CPUs
+}
+
+// CreatedTorrent is a torrent created by [CreateTorrent].
+type CreatedTorrent struct {
+	Data     []byte             // Content of the .torrent file
+	MetaInfo *metainfo.MetaInfo // Parsed Data, e.g. for the info hashes
+	SavePath string             // Parent directory of the data, the save path to seed the torrent from
+}
+
+/*
+AddOptions returns the options to add the torrent with [Client.AddNewTorrentWithOptions] and seed it right away,
+without checking the data. Change the save path if qBittorrent sees the data at another path than this machine.
+
+# Example
+
+	created, err := qbittorrent.CreateTorrent(ctx, "/data/ubuntu", &qbittorrent.CreateTorrentOptions{Trackers: trackers})
+	if err != nil {
+	 panic(err)
+	}
+
+	err = client.AddNewTorrentWithOptions(created.AddOptions().Category("Linux"))
+*/
+func (t *CreatedTorrent) AddOptions() *NewTorrentOptions {
+	return NewTorrent().
+		AddFromBytes(t.MetaInfo.Info.Name+".torrent", t.Data).
+		SavePath(t.SavePath).
+		SkipChecking(true)
+}
+
+// creatorFile is a file added to a torrent by CreateTorrent
+type creatorFile struct {
+	path       string   // path on the file system
+	components []string // path in the torrent
+	length     int64
+	piecesRoot [32]byte   // v2 merkle root
+	pieces     [][32]byte // v2 piece layer, the merkle roots of the pieces of the file
+}
+
+// pieceJob is a piece hashed by a worker of CreateTorrent
+type pieceJob struct {
+	segments []pieceSegment
+	v1       int // index of the v1 piece, -1 for a v2 only torrent
+	padTo    int // length of the v1 piece, the data is padded with zeros to this length in hybrid torrents
+	file     *creatorFile
+	piece    int // index of the piece in file, -1 for a v1 only torrent
+}
+
+type pieceSegment struct {
+	path   string
+	offset int64
+	length int64
+}
+
+/*
+CreateTorrent creates a torrent of root, a file or a directory, on the machine holding the data. The pieces are
+hashed in parallel, unlike [Client.CreateTorrentAndWait] which creates the torrent on the qBittorrent server.
+
+Only the regular files of a directory are added, symbolic links are skipped. The returned torrent is parsed back
+with the metainfo package, and can be added with [CreatedTorrent.AddOptions].
+
+Invalid options return an error matching [ErrInvalidOptions].
+*/
+func CreateTorrent(ctx context.Context, root string, opts *CreateTorrentOptions) (torrent *CreatedTorrent, err error) {
+	if opts == nil {
+		opts = &CreateTorrentOptions{}
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = TorrentFormatHybrid
+	}
+	if format != TorrentFormatV1 && format != TorrentFormatV2 && format != TorrentFormatHybrid {
+		return nil, fmt.Errorf("%w: unknown torrent format %q", ErrInvalidOptions, format)
+	}
+	if opts.PieceSize != 0 && (opts.PieceSize < blockSize || opts.PieceSize&(opts.PieceSize-1) != 0) {
+		return nil, fmt.Errorf("%w: piece size %d is not a power of two of at least 16 KiB", ErrInvalidOptions, opts.PieceSize)
+	}
+
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return
+	}
+
+	files, single, err := creatorFiles(root)
+	if err != nil {
+		return
+	}
+
+	var total int64
+	for _, file := range files {
+		total += file.length
+	}
+
+	pieceSize := opts.PieceSize
+	if pieceSize == 0 {
+		pieceSize = blockSize
+		for total/pieceSize >= maxAutoPieces && pieceSize < maxAutoPieceLen {
+			pieceSize *= 2
+		}
+	}
+
+	jobs, v1Pieces := pieceJobs(files, format, pieceSize)
+
+	v1Hashes := make([][sha1.Size]byte, v1Pieces)
+	err = hashPieces(ctx, jobs, v1Hashes, pieceSize, opts.Workers)
+	if err != nil {
+		return
+	}
+
+	if format != TorrentFormatV1 {
+		for _, file := range files {
+			file.piecesRoot = fileRoot(file, pieceSize)
+		}
+	}
+
+	data, err := metainfo.Encode(torrentDict(filepath.Base(root), files, single, format, pieceSize, v1Hashes, opts))
+	if err != nil {
+		return
+	}
+
+	meta, err := metainfo.Parse(data)
+	if err != nil {
+		return
+	}
+
+	return &CreatedTorrent{Data: data, MetaInfo: meta, SavePath: filepath.Dir(root)}, nil
+}
+
+// creatorFiles lists the files of root in the order of the torrent, sorted by path
+func creatorFiles(root string) (files []*creatorFile, single bool, err error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return
+	}
+
+	if info.Mode().IsRegular() {
+		return []*creatorFile{{path: root, components: []string{info.Name()}, length: info.Size()}}, true, nil
+	}
+
+	// WalkDir visits the entries in lexical order, which is the order of the v2 file tree
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, &creatorFile{path: path, components: strings.Split(filepath.ToSlash(rel), "/"), length: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	if len(files) == 0 {
+		return nil, false, fmt.Errorf("%w: %s has no files", ErrInvalidOptions, root)
+	}
+
+	return
+}
+
+/*
+pieceJobs splits the files into pieces.
+
+The v1 pieces of a v1 torrent span the files. The pieces of v2 and hybrid torrents are aligned on the files, and
+the v1 pieces of a hybrid torrent are padded with zeros, as if padding files were added after each file.
+*/
+func pieceJobs(files []*creatorFile, format TorrentFormat, pieceSize int64) (jobs []pieceJob, v1Pieces int) {
+	if format == TorrentFormatV1 {
+		var job pieceJob
+		for _, file := range files {
+			for offset := int64(0); offset < file.length; {
+				length := min(pieceSize-job.length(), file.length-offset)
+				job.segments = append(job.segments, pieceSegment{path: file.path, offset: offset, length: length})
+				offset += length
+
+				if job.length() == pieceSize {
+					job.v1, job.piece = v1Pieces, -1
+					jobs = append(jobs, job)
+					job = pieceJob{}
+					v1Pieces++
+				}
+			}
+		}
+		if len(job.segments) > 0 {
+			job.v1, job.piece = v1Pieces, -1
+			jobs = append(jobs, job)
+			v1Pieces++
+		}
+		return
+	}
+
+	for i, file := range files {
+		count := int((file.length + pieceSize - 1) / pieceSize)
+		file.pieces = make([][32]byte, count)
+
+		for piece := 0; piece < count; piece++ {
+			offset := int64(piece) * pieceSize
+			job := pieceJob{
+				segments: []pieceSegment{{path: file.path, offset: offset, length: min(pieceSize, file.length-offset)}},
+				v1:       -1,
+				file:     file,
+				piece:    piece,
+			}
+
+			if format == TorrentFormatHybrid {
+				job.v1 = v1Pieces
+				v1Pieces++
+				// the last file is not padded
+				if i < len(files)-1 {
+					job.padTo = int(pieceSize)
+				}
+			}
+			jobs = append(jobs, job)
+		}
+	}
+
+	return
+}
+
+func (j *pieceJob) length() (length int64) {
+	for _, segment := range j.segments {
+		length += segment.length
+	}
+	return
+}
+
+// hashPieces hashes the pieces with a pool of workers, storing the v1 hashes in v1Hashes and the v2 ones in the files
+func hashPieces(ctx context.Context, jobs []pieceJob, v1Hashes [][sha1.Size]byte, pieceSize int64, workers int) (err error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	queue := make(chan *pieceJob)
+	var wg sync.WaitGroup
+	for range min(workers, max(len(jobs), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			buf := make([]byte, pieceSize)
+			for job := range queue {
+				err := job.hash(buf, v1Hashes)
+				if err != nil {
+					cancel(err)
+				}
+			}
+		}()
+	}
+
+send:
+	for i := range jobs {
+		select {
+		case queue <- &jobs[i]:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return context.Cause(ctx)
+}
+
+func (j *pieceJob) hash(buf []byte, v1Hashes [][sha1.Size]byte) (err error) {
+	n := 0
+	for _, segment := range j.segments {
+		err = readSegment(buf[n:n+int(segment.length)], segment)
+		if err != nil {
+			return
+		}
+		n += int(segment.length)
+	}
+	data := buf[:n]
+
+	if j.v1 >= 0 {
+		h := sha1.New()
+		h.Write(data)
+		if j.padTo > n {
+			h.Write(make([]byte, j.padTo-n))
+		}
+		h.Sum(v1Hashes[j.v1][:0])
+	}
+
+	if j.piece >= 0 {
+		leaves := make([][32]byte, 0, (n+blockSize-1)/blockSize)
+		for offset := 0; offset < n; offset += blockSize {
+			leaves = append(leaves, sha256.Sum256(data[offset:min(offset+blockSize, n)]))
+		}
+
+		// a file of a single piece has a tree of the size of its blocks, the others of the size of a piece
+		width := len(buf) / blockSize
+		if len(j.file.pieces) == 1 {
+			width = nextPowerOfTwo(len(leaves))
+		}
+		j.file.pieces[j.piece] = merkleRoot(leaves, width, [32]byte{})
+	}
+
+	return
+}
+
+func readSegment(buf []byte, segment pieceSegment) (err error) {
+	file, err := os.Open(segment.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	_, err = file.ReadAt(buf, segment.offset)
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("qbittorrent: %s changed while it was hashed", segment.path)
+	}
+
+	return
+}
+
+// fileRoot returns the v2 merkle root of a file from the roots of its pieces
+func fileRoot(file *creatorFile, pieceSize int64) [32]byte {
+	switch len(file.pieces) {
+	case 0:
+		return [32]byte{}
+	case 1:
+		return file.pieces[0]
+	}
+
+	// the missing pieces are trees of zero blocks
+	var pad [32]byte
+	for width := int64(blockSize); width < pieceSize; width *= 2 {
+		pad = sha256.Sum256(append(pad[:], pad[:]...))
+	}
+
+	return merkleRoot(file.pieces, nextPowerOfTwo(len(file.pieces)), pad)
+}
+
+// merkleRoot returns the root of a tree of width leaves, the missing leaves are set to pad
+func merkleRoot(leaves [][32]byte, width int, pad [32]byte) [32]byte {
+	layer := make([][32]byte, width)
+	copy(layer, leaves)
+	for i := len(leaves); i < width; i++ {
+		layer[i] = pad
+	}
+
+	for len(layer) > 1 {
+		for i := 0; i < len(layer)/2; i++ {
+			layer[i] = sha256.Sum256(append(layer[2*i][:], layer[2*i+1][:]...))
+		}
+		layer = layer[:len(layer)/2]
+	}
+
+	return layer[0]
+}
+
+func nextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+// torrentDict builds the bencoded dictionary of the torrent
+func torrentDict(name string, files []*creatorFile, single bool, format TorrentFormat, pieceSize int64, v1Hashes [][sha1.Size]byte, opts *CreateTorrentOptions) map[string]any {
+	info := map[string]any{
+		"name":         name,
+		"piece length": pieceSize,
+	}
+	if opts.Private {
+		info["private"] = 1
+	}
+	if opts.Source != "" {
+		info["source"] = opts.Source
+	}
+
+	if format != TorrentFormatV1 {
+		info["meta version"] = 2
+
+		tree := map[string]any{}
+		for _, file := range files {
+			node := tree
+			for _, component := range file.components {
+				child, ok := node[component].(map[string]any)
+				if !ok {
+					child = map[string]any{}
+					node[component] = child
+				}
+				node = child
+			}
+
+			properties := map[string]any{"length": file.length}
+			if file.length > 0 {
+				properties["pieces root"] = file.piecesRoot[:]
+			}
+			node[""] = properties
+		}
+		info["file tree"] = tree
+	}
+
+	if format != TorrentFormatV2 {
+		pieces := make([]byte, 0, len(v1Hashes)*sha1.Size)
+		for _, hash := range v1Hashes {
+			pieces = append(pieces, hash[:]...)
+		}
+		info["pieces"] = pieces
+
+		if single {
+			info["length"] = files[0].length
+		} else {
+			var list []any
+			for i, file := range files {
+				list = append(list, map[string]any{"length": file.length, "path": file.components})
+
+				if pad := (pieceSize - file.length%pieceSize) % pieceSize; format == TorrentFormatHybrid && pad > 0 && i < len(files)-1 {
+					list = append(list, map[string]any{"attr": "p", "length": pad, "path": []string{".pad", strconv.FormatInt(pad, 10)}})
+				}
+			}
+			info["files"] = list
+		}
+	}
+
+	torrent := map[string]any{
+		"info":          info,
+		"creation date": time.Now().Unix(),
+		"created by":    defaultUserAgent,
+	}
+	if len(opts.Trackers) > 0 {
+		torrent["announce"] = opts.Trackers[0]
+
+		tiers := make([][]string, len(opts.Trackers))
+		for i, tracker := range opts.Trackers {
+			tiers[i] = []string{tracker}
+		}
+		torrent["announce-list"] = tiers
+	}
+	if len(opts.WebSeeds) > 0 {
+		torrent["url-list"] = opts.WebSeeds
+	}
+	if opts.Comment != "" {
+		torrent["comment"] = opts.Comment
+	}
+
+	// the piece layers of the files larger than a piece
+	if format != TorrentFormatV1 {
+		layers := map[string]any{}
+		for _, file := range files {
+			if len(file.pieces) > 1 {
+				layer := make([]byte, 0, len(file.pieces)*32)
+				for _, piece := range file.pieces {
+					layer = append(layer, piece[:]...)
+				}
+				layers[string(file.piecesRoot[:])] = layer
+			}
+		}
+		torrent["piece layers"] = layers
+	}
+
+	return torrent
+}
@@ -0,0 +1,189 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+)
+
+// createdFiles are the files of the torrents created by the tests, in the order of the v2 file tree:
+// the path components are sorted, so "a/b.bin" is before "a-c" even though '-' is before '/'
+var createdFiles = []struct {
+	path   string
+	length int
+}{
+	{"a/b.bin", 70000},
+	{"a/empty", 0},
+	{"a-c", 1000},
+	{"z.txt", 40000},
+}
+
+// createContent writes createdFiles in a directory named content and returns its path
+func createContent(t *testing.T) string {
+	t.Helper()
+
+	root := filepath.Join(t.TempDir(), "content")
+	for _, file := range createdFiles {
+		path := filepath.Join(root, filepath.FromSlash(file.path))
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := strings.Repeat(file.path, file.length/len(file.path)+1)[:file.length]
+		err = os.WriteFile(path, []byte(data), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func createTestTorrent(t *testing.T, root string, format qbittorrent.TorrentFormat) *metainfo.MetaInfo {
+	t.Helper()
+
+	created, err := qbittorrent.CreateTorrent(context.Background(), root, &qbittorrent.CreateTorrentOptions{
+		Format:    format,
+		PieceSize: 32 << 10,
+		Trackers:  []string{"udp://tracker.example.com:1337", "udp://backup.example.com:1337"},
+		Private:   true,
+		Source:    "TEST",
+		Comment:   "test torrent",
+		Workers:   3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the torrent is checked as read from the file
+	meta, err := metainfo.Parse(created.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.SavePath != filepath.Dir(root) || meta.Info.Name != "content" {
+		t.Fatalf("got save path %q and name %q", created.SavePath, meta.Info.Name)
+	}
+
+	return meta
+}
+
+// filePaths returns the paths of the files of meta without the padding files
+func filePaths(meta *metainfo.MetaInfo) (paths []string) {
+	for _, file := range meta.Info.Files {
+		if !file.IsPadding() {
+			paths = append(paths, strings.Join(file.Path, "/"))
+		}
+	}
+	return
+}
+
+func TestCreateTorrent(t *testing.T) {
+	// computed from the same files with an independent implementation of BEP 3 and BEP 52
+	tests := []struct {
+		format qbittorrent.TorrentFormat
+		hashV1 string
+		hashV2 string
+	}{
+		{qbittorrent.TorrentFormatV1, "ab8d3012dc11c2b6d6a3bd9838b0154efe7529b1", ""},
+		{qbittorrent.TorrentFormatV2, "", "47de836decf7408559c23ab1c815b01ec198c4ae782ec326ecced4ca336f9285"},
+		{qbittorrent.TorrentFormatHybrid, "812e89b2c47a5359baeae6e3c391f7eace557097", "cd22740ddd60c22a60f274315698e556e257c82589e6b1aedad5fbde8639eb3b"},
+	}
+
+	root := createContent(t)
+
+	var want []string
+	for _, file := range createdFiles {
+		want = append(want, file.path)
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			meta := createTestTorrent(t, root, test.format)
+
+			if meta.HashV1 != test.hashV1 || meta.HashV2 != test.hashV2 {
+				t.Fatalf("got info hashes %q %q, want %q %q", meta.HashV1, meta.HashV2, test.hashV1, test.hashV2)
+			}
+			if !meta.Info.Private || meta.Info.Source != "TEST" || meta.Comment != "test torrent" {
+				t.Fatalf("got private=%v source=%q comment=%q", meta.Info.Private, meta.Info.Source, meta.Comment)
+			}
+			if len(meta.AnnounceList) != 2 || meta.Announce != "udp://tracker.example.com:1337" {
+				t.Fatalf("got trackers %q %v", meta.Announce, meta.AnnounceList)
+			}
+
+			if paths := filePaths(meta); !reflect.DeepEqual(paths, want) {
+				t.Fatalf("got files %v, want %v", paths, want)
+			}
+		})
+	}
+}
+
+func TestCreateTorrentHybridFiles(t *testing.T) {
+	root := createContent(t)
+	hybrid := createTestTorrent(t, root, qbittorrent.TorrentFormatHybrid)
+	v2 := createTestTorrent(t, root, qbittorrent.TorrentFormatV2)
+
+	// the v1 files of a hybrid torrent are the files of the v2 file tree in the same order
+	if v1Paths, v2Paths := filePaths(hybrid), filePaths(v2); !reflect.DeepEqual(v1Paths, v2Paths) {
+		t.Fatalf("got v1 files %v, want the v2 file tree order %v", v1Paths, v2Paths)
+	}
+
+	// each file but the last one is padded to the end of its last piece
+	var offset int64
+	var padding []int64
+	for _, file := range hybrid.Info.Files {
+		if file.IsPadding() {
+			padding = append(padding, file.Length)
+			if len(file.Path) != 2 || file.Path[0] != ".pad" || file.PiecesRoot != nil {
+				t.Fatalf("got padding file %+v", file)
+			}
+		} else if offset%hybrid.Info.PieceLength != 0 {
+			t.Fatalf("%v starts at %d, not at the start of a piece", file.Path, offset)
+		}
+		offset += file.Length
+	}
+	if want := []int64{3*32<<10 - 70000, 32<<10 - 1000}; !reflect.DeepEqual(padding, want) {
+		t.Fatalf("got padding files %v, want %v", padding, want)
+	}
+	if got, want := hybrid.Info.NumPieces(), 3+2+1; got != want {
+		t.Fatalf("got %d pieces, want %d", got, want)
+	}
+
+	// only the files larger than a piece have a piece layer, the same one in both torrents
+	if !reflect.DeepEqual(hybrid.PieceLayers, v2.PieceLayers) || len(hybrid.PieceLayers) != 2 {
+		t.Fatalf("got %d piece layers, want 2", len(hybrid.PieceLayers))
+	}
+	for _, file := range v2.Info.Files {
+		layer, ok := v2.PieceLayers[string(file.PiecesRoot)]
+		if pieces := (file.Length + 32<<10 - 1) / (32 << 10); ok != (pieces > 1) || ok && int64(len(layer)) != 32*pieces {
+			t.Fatalf("%v: got a piece layer of %d bytes for %d pieces", file.Path, len(layer), pieces)
+		}
+	}
+}
+
+func TestCreateTorrentInvalidOptions(t *testing.T) {
+	root := createContent(t)
+
+	for _, opts := range []*qbittorrent.CreateTorrentOptions{
+		{Format: "v3"},
+		{PieceSize: 8 << 10},
+		{PieceSize: 48 << 10},
+	} {
+		_, err := qbittorrent.CreateTorrent(context.Background(), root, opts)
+		if !errors.Is(err, qbittorrent.ErrInvalidOptions) {
+			t.Fatalf("%+v: got error %v, want ErrInvalidOptions", opts, err)
+		}
+	}
+
+	_, err := qbittorrent.CreateTorrent(context.Background(), t.TempDir(), nil)
+	if !errors.Is(err, qbittorrent.ErrInvalidOptions) {
+		t.Fatalf("empty directory: got error %v, want ErrInvalidOptions", err)
+	}
+}
@@ -0,0 +1,91 @@
+package qbittorrent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Credentials are the username and password used to log in to the WebUI.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+/*
+CredentialProvider returns the credentials used by [Client.LoginWith] and by the automatic re-login
+when the session expires. It's called for every login, so rotated secrets are picked up without a new client.
+
+Implementations must be safe for concurrent use.
+*/
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (credentials Credentials, err error)
+}
+
+// CredentialProviderFunc is a callback implementing [CredentialProvider], e.g. to read the credentials from a secret manager.
+type CredentialProviderFunc func(ctx context.Context) (credentials Credentials, err error)
+
+func (f CredentialProviderFunc) Credentials(ctx context.Context) (credentials Credentials, err error) {
+	return f(ctx)
+}
+
+// StaticCredentials always returns the given username and password.
+func StaticCredentials(username, password string) CredentialProvider {
+	return CredentialProviderFunc(func(context.Context) (Credentials, error) {
+		return Credentials{Username: username, Password: password}, nil
+	})
+}
+
+// EnvCredentials reads the credentials from the given environment variables,
+// empty names default to QBITTORRENT_USERNAME and QBITTORRENT_PASSWORD.
+func EnvCredentials(usernameVar, passwordVar string) CredentialProvider {
+	if usernameVar == "" {
+		usernameVar = "QBITTORRENT_USERNAME"
+	}
+	if passwordVar == "" {
+		passwordVar = "QBITTORRENT_PASSWORD"
+	}
+
+	return CredentialProviderFunc(func(context.Context) (credentials Credentials, err error) {
+		var ok bool
+		credentials.Username, ok = os.LookupEnv(usernameVar)
+		if !ok {
+			return Credentials{}, fmt.Errorf("qbittorrent: environment variable %s is not set", usernameVar)
+		}
+
+		credentials.Password, ok = os.LookupEnv(passwordVar)
+		if !ok {
+			return Credentials{}, fmt.Errorf("qbittorrent: environment variable %s is not set", passwordVar)
+		}
+
+		return
+	})
+}
+
+// FileCredentials reads the username and the password from two files, e.g. Docker or Kubernetes secret mounts.
+// A trailing newline is removed from the contents of the files.
+func FileCredentials(usernamePath, passwordPath string) CredentialProvider {
+	readSecret := func(path string) (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	return CredentialProviderFunc(func(context.Context) (credentials Credentials, err error) {
+		credentials.Username, err = readSecret(usernamePath)
+		if err != nil {
+			return Credentials{}, err
+		}
+
+		credentials.Password, err = readSecret(passwordPath)
+		if err != nil {
+			return Credentials{}, err
+		}
+
+		return
+	})
+}
@@ -0,0 +1,112 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+// specialPassword has the characters that break a login form that is not encoded
+const specialPassword = "p&ss+w=rd %20?#;é"
+
+func TestLoginSpecialCharacters(t *testing.T) {
+	srv := qbittest.NewServer()
+	srv.Username = "admin+1&co"
+	srv.Password = specialPassword
+	defer srv.Close()
+
+	// the server only accepts the exact password, which a form that is not encoded would cut at "&"
+	client := qbittorrent.NewClient(srv.URL)
+	err := client.Login(srv.Username, specialPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// logging in again sends the same form
+	srv.ExpireSessions()
+	if _, err := client.GetTorrentList(nil); err != nil {
+		t.Fatalf("got error %v after logging in again", err)
+	}
+	if got := srv.Requests(loginEndpoint); got != 2 {
+		t.Fatalf("got %d logins, want 2", got)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("QBITTORRENT_USERNAME", "admin")
+	t.Setenv("QBITTORRENT_PASSWORD", " "+specialPassword+"\n")
+	t.Setenv("QBT_USER", "other")
+
+	// the values are used as they are, without trimming
+	credentials, err := qbittorrent.EnvCredentials("", "").Credentials(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (qbittorrent.Credentials{Username: "admin", Password: " " + specialPassword + "\n"}); credentials != want {
+		t.Fatalf("got %+v, want %+v", credentials, want)
+	}
+
+	credentials, err = qbittorrent.EnvCredentials("QBT_USER", "").Credentials(context.Background())
+	if err != nil || credentials.Username != "other" {
+		t.Fatalf("got %+v, %v", credentials, err)
+	}
+
+	// a variable set to an empty value is not missing
+	t.Setenv("QBT_PASS", "")
+	if _, err := qbittorrent.EnvCredentials("QBT_USER", "QBT_PASS").Credentials(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = qbittorrent.EnvCredentials("QBT_MISSING_USER", "").Credentials(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "QBT_MISSING_USER") {
+		t.Fatalf("got error %v, want the missing variable", err)
+	}
+	_, err = qbittorrent.EnvCredentials("", "QBT_MISSING_PASS").Credentials(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "QBT_MISSING_PASS") {
+		t.Fatalf("got error %v, want the missing variable", err)
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	srv := qbittest.NewServer()
+	srv.Password = specialPassword + " "
+	defer srv.Close()
+
+	dir := t.TempDir()
+	usernamePath := filepath.Join(dir, "username")
+	passwordPath := filepath.Join(dir, "password")
+	os.WriteFile(usernamePath, []byte(qbittest.DefaultUsername+"\n"), 0o600)
+	// only the trailing newline is removed, the space is part of the password
+	os.WriteFile(passwordPath, []byte(specialPassword+" \r\n"), 0o600)
+
+	client := qbittorrent.NewClient(srv.URL)
+	err := client.LoginWith(qbittorrent.FileCredentials(usernamePath, passwordPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missing := filepath.Join(dir, "missing")
+	for _, provider := range []qbittorrent.CredentialProvider{
+		qbittorrent.FileCredentials(missing, passwordPath),
+		qbittorrent.FileCredentials(usernamePath, missing),
+	} {
+		if _, err := provider.Credentials(context.Background()); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("got error %v, want os.ErrNotExist", err)
+		}
+	}
+
+	// the provider error is returned without sending the login
+	logins := srv.Requests(loginEndpoint)
+	if err := client.LoginWith(qbittorrent.FileCredentials(usernamePath, missing)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("got error %v, want os.ErrNotExist", err)
+	}
+	if got := srv.Requests(loginEndpoint); got != logins {
+		t.Fatal("logged in without credentials")
+	}
+}
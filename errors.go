@@ -0,0 +1,76 @@
+package qbittorrent
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const loginEndpoint = "/api/v2/auth/login"
+
+// Sentinel errors, use them with errors.Is to check the cause of an [APIError].
+var (
+	ErrBadRequest           = errors.New("bad request")                                     // 400, e.g. a parameter is missing or invalid
+	ErrForbidden            = errors.New("forbidden")                                       // 403, the client is not authorized
+	ErrNotFound             = errors.New("not found")                                       // 404, e.g. the torrent hash or search job was not found
+	ErrConflict             = errors.New("conflict")                                        // 409, e.g. too many running searches or the tracker URL already exists
+	ErrUnsupportedMediaType = errors.New("unsupported media type")                          // 415, e.g. the torrent file is not valid
+	ErrBanned               = errors.New("ip is banned for too many failed login attempts") // 403 returned by the login endpoint
+	ErrLoginFailed          = errors.New("login failed, wrong username or password")        // The login endpoint answered with "Fails."
+	ErrSessionRejected      = errors.New("session rejected after logging in again")         // A request was still answered with 403 after logging in again
+	ErrNoSession            = errors.New("no session")                                      // The client is not logged in, or the session to import is empty
+	ErrUnsupported          = errors.New("not supported by the server")                     // The WebAPI version of the server is too old for the method, see [Client.Capabilities]
+	ErrInvalidOptions       = errors.New("invalid options")                                 // The options were rejected before sending the request, e.g. by [NewTorrentOptions.Validate]
+	ErrInvalidInfoHash      = errors.New("invalid info hash")                               // Returned by [ParseInfoHash]
+	ErrInvalidMagnet        = errors.New("invalid magnet link")                             // Returned by [ParseMagnet]
+	ErrAddFailed            = errors.New("no torrent was added")                            // The add endpoint answered with "Fails.", e.g. every torrent was already in qBittorrent
+)
+
+// APIError is returned when qBittorrent answers a request with a non-200 status code.
+//
+// It matches the sentinel errors of this package, for example:
+//
+//	if errors.Is(err, qbittorrent.ErrNotFound) {
+//		// the torrent hash is invalid
+//	}
+type APIError struct {
+	StatusCode int    // HTTP status code of the response
+	Method     string // HTTP method of the request
+	Endpoint   string // API endpoint of the request (e.g. /api/v2/torrents/info)
+	Body       string // Response body, usually a short message from qBittorrent
+}
+
+func newAPIError(req *http.Request, resp *http.Response, endpoint string, body []byte) *APIError {
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		Endpoint:   endpoint,
+		Body:       string(body),
+	}
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s %s: %d %s", e.Method, e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// Is reports whether the error matches one of the sentinel errors of this package.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnsupportedMediaType:
+		return e.StatusCode == http.StatusUnsupportedMediaType
+	case ErrBanned:
+		return e.StatusCode == http.StatusForbidden && e.Endpoint == loginEndpoint
+	}
+	return false
+}
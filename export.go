@@ -0,0 +1,148 @@
+package qbittorrent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// number of torrents exported at the same time by ExportTorrents
+const exportWorkers = 4
+
+// ExportResult is the outcome of exporting a single torrent with [Client.ExportTorrents].
+type ExportResult struct {
+	Hash string // Torrent hash
+	Path string // Path of the written file, empty when Err is set
+	Err  error  // Why the torrent was not exported
+}
+
+/*
+ExportTorrents saves the .torrent files of the given torrents in dir as `<name>.<hash>.torrent`,
+exporting a few torrents at the same time. Pass []string{"all"} to export every torrent.
+A nil hashes also exports every torrent on the server, while an empty non-nil slice exports nothing.
+
+The results are in the same order as hashes, a torrent that fails to export has its [ExportResult.Err] set
+and doesn't stop the others. Once ctx is done, the torrents not exported yet fail with the error of ctx.
+The returned error is only set when the torrent list can't be requested or dir can't be created.
+
+Requires WebAPI v2.8.14 (qBittorrent 4.5.0) or newer, see [Client.Capabilities].
+
+# Example
+
+	results, err := client.ExportTorrents(ctx, []string{"all"}, "/backup/torrents")
+	if err != nil {
+	 panic(err)
+	}
+
+	for _, result := range results {
+	 if result.Err != nil {
+	  fmt.Println(result.Hash, result.Err)
+	 }
+	}
+*/
+func (c *Client) ExportTorrents(ctx context.Context, hashes []string, dir string) (results []ExportResult, err error) {
+	err = c.requireAPIVersion(ctx, "exporting torrents", apiVersionExportTorrent)
+	if err != nil {
+		return
+	}
+
+	opts := &GetTorrentListOptions{}
+	if len(hashes) != 1 || hashes[0] != "all" {
+		opts.Hashes = hashes
+	}
+	torrents, err := c.GetTorrentListCtx(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	// the server returns lowercase hashes, the caller may not
+	names := make(map[string]string, len(torrents))
+	for _, torrent := range torrents {
+		names[strings.ToLower(torrent.Hash)] = torrent.Name
+	}
+	if opts.Hashes == nil {
+		hashes = make([]string, 0, len(torrents))
+		for _, torrent := range torrents {
+			hashes = append(hashes, torrent.Hash)
+		}
+	}
+
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return
+	}
+
+	results = make([]ExportResult, len(hashes))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(exportWorkers, len(hashes)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					results[i] = ExportResult{Hash: hashes[i], Err: err}
+					continue
+				}
+				results[i] = c.exportTorrentFile(ctx, hashes[i], names, dir)
+			}
+		}()
+	}
+
+	for i := range hashes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// exportTorrentFile exports a single torrent for ExportTorrents
+func (c *Client) exportTorrentFile(ctx context.Context, hash string, names map[string]string, dir string) (result ExportResult) {
+	result.Hash = hash
+	hash = strings.ToLower(hash)
+
+	name, ok := names[hash]
+	if !ok {
+		result.Err = fmt.Errorf("qbittorrent: torrent %s: %w", hash, ErrNotFound)
+		return
+	}
+
+	torrent, err := c.ExportTorrentCtx(ctx, hash)
+	if err != nil {
+		result.Err = err
+		return
+	}
+
+	path := filepath.Join(dir, sanitizeFileName(name)+"."+hash+".torrent")
+	err = os.WriteFile(path, torrent, 0o644)
+	if err != nil {
+		result.Err = err
+		return
+	}
+
+	result.Path = path
+	return
+}
+
+// sanitizeFileName replaces the characters that are not allowed in file names on common file systems
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+
+	name = strings.Trim(name, " .")
+	if name == "" {
+		name = "torrent"
+	}
+
+	return name
+}
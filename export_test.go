@@ -0,0 +1,145 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+)
+
+const exportEndpoint = "/api/v2/torrents/export"
+
+// addTestTorrents adds n torrents from .torrent files and returns their hashes
+func addTestTorrents(t *testing.T, client *qbittorrent.Client, n int) (hashes []string) {
+	t.Helper()
+
+	torrent := qbittorrent.NewTorrent()
+	for i := range n {
+		data := testTorrent(t, fmt.Sprintf("torrent %d", i), 1<<10)
+		meta, err := metainfo.Parse(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		torrent.AddFromBytes(fmt.Sprintf("%d.torrent", i), data)
+		hashes = append(hashes, meta.Hash())
+	}
+
+	err := client.AddNewTorrentWithOptions(torrent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return
+}
+
+func TestExportTorrents(t *testing.T) {
+	_, client := newTestClient(t)
+	hashes := addTestTorrents(t, client, 2)
+	dir := t.TempDir()
+
+	upper := strings.ToUpper(hashes[1])
+	results, err := client.ExportTorrents(context.Background(), []string{hashes[0], upper, ubuntuHash}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, hash := range hashes {
+		result := results[i]
+		if result.Err != nil {
+			t.Fatalf("%s: %v", result.Hash, result.Err)
+		}
+		if want := filepath.Join(dir, fmt.Sprintf("torrent %d.%s.torrent", i, hash)); result.Path != want {
+			t.Fatalf("got path %q, want %q", result.Path, want)
+		}
+		if _, err := metainfo.ReadFile(result.Path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if results[1].Hash != upper {
+		t.Fatalf("got hash %q, want the one given %q", results[1].Hash, upper)
+	}
+
+	if !errors.Is(results[2].Err, qbittorrent.ErrNotFound) {
+		t.Fatalf("missing torrent: got error %v, want ErrNotFound", results[2].Err)
+	}
+}
+
+func TestExportTorrentsCancel(t *testing.T) {
+	const n = 20
+
+	srv, client := newTestClient(t)
+	addTestTorrents(t, client, n)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv.AddHook(func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == exportEndpoint {
+			cancel()
+		}
+		return false
+	})
+
+	results, err := client.ExportTorrents(ctx, []string{"all"}, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	canceled := 0
+	for _, result := range results {
+		if errors.Is(result.Err, context.Canceled) {
+			canceled++
+		}
+	}
+
+	// only the exports already started by the workers when ctx was canceled reach the server
+	if exports := srv.Requests(exportEndpoint); exports > 4 || canceled < n-4 {
+		t.Fatalf("got %d exports and %d canceled results after canceling, want the remaining jobs skipped", exports, canceled)
+	}
+}
+
+func TestExportTorrentsUnsupported(t *testing.T) {
+	_, client := newTestClientVersion(t, "2.8.3")
+
+	_, err := client.ExportTorrents(context.Background(), []string{"all"}, t.TempDir())
+	if !errors.Is(err, qbittorrent.ErrUnsupported) {
+		t.Fatalf("got error %v, want ErrUnsupported", err)
+	}
+}
+
+func TestExportTorrentsCreatesDir(t *testing.T) {
+	_, client := newTestClient(t)
+	addTestTorrents(t, client, 1)
+
+	dir := filepath.Join(t.TempDir(), "backup", "torrents")
+	results, err := client.ExportTorrents(context.Background(), []string{"all"}, dir)
+	if err != nil || len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("got %+v, %v", results, err)
+	}
+	if _, err := os.Stat(results[0].Path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportTorrentsNilHashes(t *testing.T) {
+	_, client := newTestClient(t)
+	addTestTorrents(t, client, 2)
+
+	results, err := client.ExportTorrents(context.Background(), nil, t.TempDir())
+	if err != nil || len(results) != 2 {
+		t.Fatalf("got %+v, %v, want every torrent exported", results, err)
+	}
+
+	results, err = client.ExportTorrents(context.Background(), []string{}, t.TempDir())
+	if err != nil || len(results) != 0 {
+		t.Fatalf("got %+v, %v, want nothing exported", results, err)
+	}
+}
@@ -0,0 +1,256 @@
+package qbittorrent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+)
+
+/*
+FileSelection selects the files of a torrent to download, see [Client.AddTorrentsWithFileSelection].
+
+A file is downloaded when it matches one of the Include patterns (or Include is empty), none of the Exclude
+patterns, and Filter returns true (or Filter is nil). The patterns use the syntax of [path.Match], a pattern
+without a "/" is matched against the base name of the file, the others against its full name.
+
+# Example
+
+	selection := &qbittorrent.FileSelection{
+	 Include: []string{"*S01E0[1-4]*.mkv"},
+	 Exclude: []string{"*sample*"},
+	 Filter:  func(file qbittorrent.TorrentFile) bool { return file.Size > 100<<20 },
+	}
+*/
+type FileSelection struct {
+	Include      []string                    // Patterns of the files to download, empty to include every file
+	Exclude      []string                    // Patterns of the files not to download, applied after Include
+	Filter       func(file TorrentFile) bool // Optional predicate, the file is only downloaded if it returns true
+	DryRun       bool                        // Only report the selection, without adding the torrents. It needs the .torrent files, as a magnet link has no file list before it's added
+	PollInterval time.Duration               // Interval between two torrent list requests while waiting for the metadata, defaults to 1.5 seconds
+}
+
+// FileSelectionResult is the outcome of adding a single torrent with [Client.AddTorrentsWithFileSelection].
+type FileSelectionResult struct {
+	AddResult
+	Selected []TorrentFile // Files that are downloaded
+	Skipped  []TorrentFile // Files set to [FilePriorityDoNotDownload]
+	Err      error         // Why the selection was not applied, the torrent may have been added anyway
+}
+
+// Validate checks the patterns, an invalid pattern returns an error matching [ErrInvalidOptions].
+func (s *FileSelection) Validate() (err error) {
+	for _, pattern := range append(append([]string{}, s.Include...), s.Exclude...) {
+		_, err = path.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("%w: file pattern %q: %w", ErrInvalidOptions, pattern, err)
+		}
+	}
+
+	return
+}
+
+// Select splits files into the ones to download and the ones to skip.
+func (s *FileSelection) Select(files []TorrentFile) (selected, skipped []TorrentFile) {
+	for _, file := range files {
+		if s.selects(file) {
+			selected = append(selected, file)
+		} else {
+			skipped = append(skipped, file)
+		}
+	}
+
+	return
+}
+
+func (s *FileSelection) selects(file TorrentFile) bool {
+	if len(s.Include) > 0 && !matchAny(s.Include, file.Name) {
+		return false
+	}
+	if matchAny(s.Exclude, file.Name) {
+		return false
+	}
+
+	return s.Filter == nil || s.Filter(file)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		target := name
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(name)
+		}
+
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+AddTorrentsWithFileSelection adds torrents like [Client.AddTorrents], and only downloads the files selected by selection.
+
+The torrents are added with [StopConditionMetadataReceived], so they stop once qBittorrent has their file list.
+The files that are not selected are set to [FilePriorityDoNotDownload], then the torrents are started, unless
+they were added stopped. Servers older than WebAPI v2.8.14 don't support the stop condition, so the torrents are
+added stopped instead, unless there is a magnet link as it would never receive its metadata. The selection of a
+running torrent is applied once it has its metadata. A torrent whose file list is still empty gets an error in
+its result and is not started.
+
+Duplicates are not changed, and the torrents added from an http URL are added without applying the selection as
+their hash is not known, both have no selected files.
+
+With selection.DryRun, nothing is sent to qBittorrent and the selection is computed from the .torrent files,
+the file names are the ones of the Original content layout.
+
+# Example
+
+	torrent := qbittorrent.NewTorrent().
+		AddFromFile("season.torrent").
+		Category("TV")
+
+	results, err := client.AddTorrentsWithFileSelection(ctx, torrent, &qbittorrent.FileSelection{Include: []string{"*.mkv"}})
+	if err != nil {
+	 panic(err)
+	}
+
+	for _, file := range results[0].Selected {
+	 fmt.Println(file.Name)
+	}
+*/
+func (c *Client) AddTorrentsWithFileSelection(ctx context.Context, torrent *NewTorrentOptions, selection *FileSelection) (results []FileSelectionResult, err error) {
+	err = torrent.Validate()
+	if err != nil {
+		return
+	}
+
+	err = selection.Validate()
+	if err != nil {
+		return
+	}
+
+	entries, err := torrent.addEntries()
+	if err != nil {
+		return
+	}
+
+	results = make([]FileSelectionResult, len(entries))
+
+	if selection.DryRun {
+		for i, entry := range entries {
+			results[i].AddResult = entry.result
+			if entry.meta == nil {
+				results[i].Err = errors.New("qbittorrent: the files of a torrent are only known once it's added")
+				continue
+			}
+			results[i].Selected, results[i].Skipped = selection.Select(metainfoFiles(entry.meta))
+		}
+		return
+	}
+
+	// the torrents are only stopped once their files are selected, as a stopped magnet link never receives its metadata
+	data := maps.Clone(torrent.Data)
+	stopped := data["stopped"] == "true" || data["paused"] == "true"
+	delete(data, "stopped")
+	delete(data, "paused")
+
+	caps, err := c.CapabilitiesCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMagnet := false
+	for _, entry := range entries {
+		hasMagnet = hasMagnet || entry.magnet
+	}
+
+	running := false
+	switch {
+	case caps.StopCondition:
+		data["stopCondition"] = string(StopConditionMetadataReceived)
+	case hasMagnet:
+		running = true
+	default:
+		data["stopped"] = "true"
+		data["paused"] = "true"
+	}
+
+	err = c.addTorrentEntries(ctx, data, entries, &AddTorrentsOptions{WaitMetadata: true, PollInterval: selection.PollInterval})
+
+	var hashes []string
+	for i, entry := range entries {
+		results[i].AddResult = entry.result
+		if err != nil || entry.result.Duplicate || entry.result.Hash == "" {
+			continue
+		}
+
+		results[i].Selected, results[i].Skipped, results[i].Err = c.applyFileSelection(ctx, entry.result.Hash, selection)
+		if results[i].Err == nil {
+			hashes = append(hashes, entry.result.Hash)
+		}
+	}
+	if err != nil || len(hashes) == 0 {
+		return
+	}
+
+	switch {
+	case !stopped:
+		err = c.StartTorrentsCtx(ctx, hashes)
+	case running:
+		err = c.StopTorrentsCtx(ctx, hashes)
+	}
+
+	return
+}
+
+// applyFileSelection sets the priority of the files of a torrent that are not selected
+func (c *Client) applyFileSelection(ctx context.Context, hash string, selection *FileSelection) (selected, skipped []TorrentFile, err error) {
+	files, err := c.GetTorrentContentsCtx(ctx, hash)
+	if err != nil {
+		return
+	}
+
+	// starting the torrent now would download every file
+	if len(files) == 0 {
+		return nil, nil, errors.New("qbittorrent: the torrent has no files, its metadata was not received")
+	}
+
+	selected, skipped = selection.Select(files)
+	if len(skipped) == 0 {
+		return
+	}
+
+	ids := make([]int, len(skipped))
+	for i, file := range skipped {
+		ids[i] = file.Index
+		skipped[i].Priority = FilePriorityDoNotDownload
+	}
+
+	err = c.SetFilePriorityCtx(ctx, hash, ids, FilePriorityDoNotDownload)
+	return
+}
+
+// metainfoFiles returns the files of a .torrent file as listed by qBittorrent, without the padding files
+func metainfoFiles(meta *metainfo.MetaInfo) (files []TorrentFile) {
+	for _, file := range meta.Info.Files {
+		if file.IsPadding() {
+			continue
+		}
+
+		name := path.Join(file.Path...)
+		if !meta.Info.SingleFile {
+			name = path.Join(meta.Info.Name, name)
+		}
+
+		files = append(files, TorrentFile{Index: len(files), Name: name, Size: file.Length, Priority: FilePriorityNormal})
+	}
+
+	return
+}
@@ -0,0 +1,352 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+// oldAPIVersion is the WebAPI version of a server without the stop conditions
+const oldAPIVersion = "2.8.3"
+
+// seasonFiles are the files of the torrents of the file selection tests
+var seasonFiles = []string{"Show/E01.mkv", "Show/E02.mkv", "Show/Sample/E01.sample.mkv", "Show/info.nfo"}
+
+// seasonSelection selects the episodes of seasonFiles
+func seasonSelection() *qbittorrent.FileSelection {
+	return &qbittorrent.FileSelection{Include: []string{"*.mkv"}, Exclude: []string{"*sample*"}, PollInterval: 10 * time.Millisecond}
+}
+
+// seasonTorrent returns a multi-file .torrent with seasonFiles
+func seasonTorrent(t *testing.T) []byte {
+	t.Helper()
+
+	var files []any
+	for _, file := range seasonFiles {
+		files = append(files, map[string]any{"length": int64(1 << 10), "path": strings.Split(strings.TrimPrefix(file, "Show/"), "/")})
+	}
+
+	data, err := metainfo.Encode(map[string]any{"info": map[string]any{
+		"name":         "Show",
+		"piece length": int64(16 << 10),
+		"pieces":       strings.Repeat("p", 20),
+		"files":        files,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+// recordAdds records the form of the add requests
+func recordAdds(srv *qbittest.Server) func() []map[string]string {
+	var mu sync.Mutex
+	var forms []map[string]string
+
+	srv.AddHook(func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path != addEndpoint {
+			return false
+		}
+
+		r.ParseMultipartForm(32 << 20)
+		form := map[string]string{}
+		for key := range r.Form {
+			form[key] = r.Form.Get(key)
+		}
+
+		mu.Lock()
+		forms = append(forms, form)
+		mu.Unlock()
+		return false
+	})
+
+	return func() []map[string]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return forms
+	}
+}
+
+// receiveMetadata simulates the metadata of a magnet link being downloaded once it's added
+func receiveMetadata(srv *qbittest.Server, hash string, state qbittorrent.TorrentState) {
+	go func() {
+		for {
+			if info, ok := srv.Torrent(hash); ok && info.State == qbittorrent.TorrentStateMetaDL {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		setSeasonFiles(srv, hash, state)
+	}()
+}
+
+// setSeasonFiles gives a magnet link the files of seasonFiles and moves it to state, like receiving its metadata
+func setSeasonFiles(srv *qbittest.Server, hash string, state qbittorrent.TorrentState) {
+	files := make([]qbittorrent.TorrentFile, len(seasonFiles))
+	for i, name := range seasonFiles {
+		files[i] = qbittorrent.TorrentFile{Name: name, Size: 1 << 10, Priority: qbittorrent.FilePriorityNormal}
+	}
+	srv.SetTorrentFiles(hash, files)
+	srv.UpdateTorrent(hash, func(info *qbittorrent.TorrentListResponse) { info.State = state })
+}
+
+// checkSelection checks the result and the file priorities set on the server
+func checkSelection(t *testing.T, client *qbittorrent.Client, result qbittorrent.FileSelectionResult) {
+	t.Helper()
+
+	if result.Err != nil {
+		t.Fatal(result.Err)
+	}
+
+	var selected []string
+	for _, file := range result.Selected {
+		selected = append(selected, file.Name)
+	}
+	if want := []string{"Show/E01.mkv", "Show/E02.mkv"}; !reflect.DeepEqual(selected, want) {
+		t.Fatalf("got selected files %v, want %v", selected, want)
+	}
+
+	files, err := client.GetTorrentContents(result.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		skipped := file.Priority == qbittorrent.FilePriorityDoNotDownload
+		if want := !strings.HasSuffix(file.Name, "E01.mkv") && !strings.HasSuffix(file.Name, "E02.mkv") || strings.Contains(file.Name, "sample"); skipped != want {
+			t.Fatalf("%s: got priority %d", file.Name, file.Priority)
+		}
+	}
+}
+
+func torrentState(t *testing.T, srv *qbittest.Server, hash string) qbittorrent.TorrentState {
+	t.Helper()
+
+	info, ok := srv.Torrent(hash)
+	if !ok {
+		t.Fatalf("torrent %s was not added", hash)
+	}
+	return info.State
+}
+
+func TestFileSelectionDryRun(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	torrent := qbittorrent.NewTorrent().AddFromBytes("show.torrent", seasonTorrent(t)).AddUrl(ubuntuMagnet)
+	selection := seasonSelection()
+	selection.DryRun = true
+
+	results, err := client.AddTorrentsWithFileSelection(context.Background(), torrent, selection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results[0].Selected) != 2 || len(results[0].Skipped) != 2 || results[0].Err != nil {
+		t.Fatalf("got %+v, want 2 selected and 2 skipped files", results[0])
+	}
+	// the files of a magnet link are not known before adding it
+	if results[1].Err == nil {
+		t.Fatal("got a selection for a magnet link")
+	}
+	if len(srv.Torrents()) != 0 || srv.Requests(addEndpoint) != 0 {
+		t.Fatal("the dry run added torrents")
+	}
+}
+
+func TestFileSelectionInvalidPattern(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	_, err := client.AddTorrentsWithFileSelection(context.Background(), qbittorrent.NewTorrent().AddUrl(ubuntuMagnet), &qbittorrent.FileSelection{Include: []string{"[x"}})
+	if !errors.Is(err, qbittorrent.ErrInvalidOptions) {
+		t.Fatalf("got error %v, want ErrInvalidOptions", err)
+	}
+	if srv.Requests(addEndpoint) != 0 {
+		t.Fatal("the torrent was added")
+	}
+}
+
+func TestFileSelectionFilter(t *testing.T) {
+	_, client := newTestClient(t)
+
+	selection := seasonSelection()
+	selection.Filter = func(file qbittorrent.TorrentFile) bool { return !strings.Contains(file.Name, "E02") }
+	selection.DryRun = true
+
+	results, err := client.AddTorrentsWithFileSelection(context.Background(), qbittorrent.NewTorrent().AddFromBytes("show.torrent", seasonTorrent(t)), selection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results[0].Selected) != 1 || results[0].Selected[0].Name != "Show/E01.mkv" {
+		t.Fatalf("got selected files %+v, want Show/E01.mkv", results[0].Selected)
+	}
+}
+
+func TestFileSelection(t *testing.T) {
+	for _, apiVersion := range []string{qbittest.DefaultAPIVersion, oldAPIVersion} {
+		for _, stopped := range []bool{false, true} {
+			name := apiVersion
+			if stopped {
+				name += " stopped"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				srv, client := newTestClientVersion(t, apiVersion)
+				adds := recordAdds(srv)
+
+				torrent := qbittorrent.NewTorrent().AddFromBytes("show.torrent", seasonTorrent(t)).Category("TV")
+				if stopped {
+					torrent.Stopped(true)
+				}
+
+				results, err := client.AddTorrentsWithFileSelection(context.Background(), torrent, seasonSelection())
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkSelection(t, client, results[0])
+
+				// the torrent is stopped while the files are selected
+				form := adds()[0]
+				if apiVersion == oldAPIVersion {
+					if form["paused"] != "true" || form["stopCondition"] != "" {
+						t.Fatalf("got add request %v, want the torrent added paused", form)
+					}
+				} else if form["stopCondition"] != string(qbittorrent.StopConditionMetadataReceived) || form["stopped"] == "true" {
+					t.Fatalf("got add request %v, want the MetadataReceived stop condition", form)
+				}
+
+				if state := torrentState(t, srv, results[0].Hash); state.IsStopped() != stopped {
+					t.Fatalf("got state %q, want stopped=%v", state, stopped)
+				}
+			})
+		}
+	}
+}
+
+func TestFileSelectionMagnet(t *testing.T) {
+	for _, apiVersion := range []string{qbittest.DefaultAPIVersion, oldAPIVersion} {
+		for _, stopped := range []bool{false, true} {
+			name := apiVersion
+			if stopped {
+				name += " stopped"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				srv, client := newTestClientVersion(t, apiVersion)
+				adds := recordAdds(srv)
+
+				// with the stop condition the torrent stops once it has its metadata, old servers keep it running
+				metadataState := qbittorrent.TorrentStateStoppedDL
+				if apiVersion == oldAPIVersion {
+					metadataState = qbittorrent.TorrentStateStalledDL
+				}
+				receiveMetadata(srv, ubuntuHash, metadataState)
+
+				torrent := qbittorrent.NewTorrent().AddUrl(ubuntuMagnet)
+				if stopped {
+					torrent.Stopped(true)
+				}
+
+				results, err := client.AddTorrentsWithFileSelection(context.Background(), torrent, seasonSelection())
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkSelection(t, client, results[0])
+
+				// a stopped magnet link never receives its metadata, the torrent is only stopped once the files are selected
+				form := adds()[0]
+				if form["stopped"] == "true" || form["paused"] == "true" {
+					t.Fatalf("got add request %v, want the magnet link added running", form)
+				}
+				if apiVersion == oldAPIVersion && form["stopCondition"] != "" {
+					t.Fatalf("got add request %v, want no stop condition", form)
+				}
+
+				if state := torrentState(t, srv, ubuntuHash); state.IsStopped() != stopped {
+					t.Fatalf("got state %q, want stopped=%v", state, stopped)
+				}
+			})
+		}
+	}
+}
+
+func TestFileSelectionQueuedMagnet(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	// queued before it received its metadata, the selection waits for its files instead of its state
+	go func() {
+		for {
+			if info, ok := srv.Torrent(ubuntuHash); ok && info.State == qbittorrent.TorrentStateMetaDL {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		srv.UpdateTorrent(ubuntuHash, func(info *qbittorrent.TorrentListResponse) { info.State = qbittorrent.TorrentStateQueuedDL })
+
+		polls := srv.Requests(torrentsEndpoint)
+		for srv.Requests(torrentsEndpoint) < polls+3 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		setSeasonFiles(srv, ubuntuHash, qbittorrent.TorrentStateStoppedDL)
+	}()
+
+	results, err := client.AddTorrentsWithFileSelection(context.Background(), qbittorrent.NewTorrent().AddUrl(ubuntuMagnet), seasonSelection())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSelection(t, client, results[0])
+	if state := torrentState(t, srv, ubuntuHash); state.IsStopped() {
+		t.Fatalf("got state %q, want the torrent started", state)
+	}
+}
+
+func TestFileSelectionNoFiles(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	// a size without a file list, the torrent can't be started without downloading every file
+	go func() {
+		for {
+			if _, ok := srv.Torrent(ubuntuHash); ok {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		srv.UpdateTorrent(ubuntuHash, func(info *qbittorrent.TorrentListResponse) {
+			info.State = qbittorrent.TorrentStateStoppedDL
+			info.TotalSize = 1 << 20
+		})
+	}()
+
+	results, err := client.AddTorrentsWithFileSelection(context.Background(), qbittorrent.NewTorrent().AddUrl(ubuntuMagnet), seasonSelection())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Err == nil || len(results[0].Selected) != 0 {
+		t.Fatalf("got %+v, want an error for the empty file list", results[0])
+	}
+	if state := torrentState(t, srv, ubuntuHash); !state.IsStopped() {
+		t.Fatalf("got state %q, want the torrent left stopped", state)
+	}
+}
+
+func TestFileSelectionDuplicate(t *testing.T) {
+	srv, client := newTestClient(t)
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: ubuntuHash, Name: "ubuntu"})
+
+	results, err := client.AddTorrentsWithFileSelection(context.Background(), qbittorrent.NewTorrent().AddUrl(ubuntuMagnet), seasonSelection())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Duplicate || results[0].Selected != nil || srv.Requests(addEndpoint) != 0 {
+		t.Fatalf("got %+v, want an unchanged duplicate", results[0])
+	}
+}
@@ -0,0 +1,103 @@
+package qbittorrent
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+/*
+InfoHash is a normalized torrent info hash in lowercase hex: 40 characters for a v1 hash, 64 for a v2 hash.
+Create it with [ParseInfoHash].
+
+Pass [InfoHash.ID] to the methods taking a torrent hash, and [HashStrings] for the ones taking a list of hashes.
+*/
+type InfoHash string
+
+// multihash prefix of a SHA-256 digest, used by the v2 magnet links (urn:btmh:)
+const sha256Multihash = "1220"
+
+/*
+ParseInfoHash validates and normalizes an info hash, it accepts:
+  - a v1 hash as 40 hex or 32 base32 characters
+  - a v2 hash as 64 hex characters, or as a 68 characters SHA-256 multihash
+  - the same hashes prefixed by "urn:btih:" (v1) or "urn:btmh:" (v2), as found in magnet links
+
+An invalid hash returns an error matching [ErrInvalidInfoHash].
+*/
+func ParseInfoHash(s string) (hash InfoHash, err error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidInfoHash, s)
+
+	v := s
+	if urn, ok := strings.CutPrefix(s, "urn:btih:"); ok {
+		if len(urn) != 40 && len(urn) != 32 {
+			return "", invalid
+		}
+		v = urn
+	} else if urn, ok := strings.CutPrefix(s, "urn:btmh:"); ok {
+		if len(urn) != 68 {
+			return "", invalid
+		}
+		v = urn
+	}
+
+	switch len(v) {
+	case 32:
+		b, err := base32.StdEncoding.DecodeString(strings.ToUpper(v))
+		if err != nil {
+			return "", invalid
+		}
+		return InfoHash(hex.EncodeToString(b)), nil
+	case 68:
+		var ok bool
+		v, ok = strings.CutPrefix(v, sha256Multihash)
+		if !ok {
+			return "", invalid
+		}
+	case 40, 64:
+	default:
+		return "", invalid
+	}
+
+	if _, err := hex.DecodeString(v); err != nil {
+		return "", invalid
+	}
+
+	return InfoHash(strings.ToLower(v)), nil
+}
+
+// String returns the hash in lowercase hex.
+func (h InfoHash) String() string {
+	return string(h)
+}
+
+// IsV2 returns true if h is a v2 (SHA-256) info hash.
+func (h InfoHash) IsV2() bool {
+	return len(h) == 64
+}
+
+// ID returns the hash identifying the torrent in qBittorrent, which is the v2 hash truncated to 20 bytes for a v2 hash.
+func (h InfoHash) ID() string {
+	if h.IsV2() {
+		return string(h[:40])
+	}
+	return string(h)
+}
+
+// URN returns the hash as used in the xt parameter of a magnet link.
+func (h InfoHash) URN() string {
+	if h.IsV2() {
+		return "urn:btmh:" + sha256Multihash + string(h)
+	}
+	return "urn:btih:" + string(h)
+}
+
+// HashStrings returns the IDs of hashes, for the methods taking a list of torrent hashes.
+func HashStrings(hashes ...InfoHash) []string {
+	results := make([]string, len(hashes))
+	for i, hash := range hashes {
+		results[i] = hash.ID()
+	}
+	return results
+}
@@ -0,0 +1,66 @@
+package qbittorrent_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+const (
+	ubuntuHashBase32 = "RQQSO6NUVPPHY26GBADDUDIARN7EBTRS"
+	v2Hash           = "caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e"
+)
+
+func TestParseInfoHash(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want qbittorrent.InfoHash
+	}{
+		{"v1 hex", ubuntuHash, ubuntuHash},
+		{"v1 uppercase hex", strings.ToUpper(ubuntuHash), ubuntuHash},
+		{"v1 base32", ubuntuHashBase32, ubuntuHash},
+		{"v1 lowercase base32", strings.ToLower(ubuntuHashBase32), ubuntuHash},
+		{"v1 urn", "urn:btih:" + ubuntuHash, ubuntuHash},
+		{"v1 base32 urn", "urn:btih:" + ubuntuHashBase32, ubuntuHash},
+		{"v2 hex", v2Hash, v2Hash},
+		{"v2 multihash", "1220" + v2Hash, v2Hash},
+		{"v2 urn", "urn:btmh:1220" + v2Hash, v2Hash},
+		{"empty", "", ""},
+		{"too short", ubuntuHash[:39], ""},
+		{"not hex", "z" + ubuntuHash[1:], ""},
+		{"not base32", "1" + ubuntuHashBase32[1:], ""},
+		{"v2 in a v1 urn", "urn:btih:" + v2Hash, ""},
+		{"v2 urn without multihash", "urn:btmh:" + v2Hash, ""},
+		{"other multihash", "1320" + v2Hash, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := qbittorrent.ParseInfoHash(tt.in)
+			if tt.want == "" {
+				if !errors.Is(err, qbittorrent.ErrInvalidInfoHash) {
+					t.Fatalf("got %q, %v, want ErrInvalidInfoHash", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfoHashID(t *testing.T) {
+	v1 := qbittorrent.InfoHash(ubuntuHash)
+	v2 := qbittorrent.InfoHash(v2Hash)
+
+	if v1.IsV2() || v1.ID() != ubuntuHash || v1.URN() != "urn:btih:"+ubuntuHash {
+		t.Fatalf("got v2 %v, ID %q and URN %q for a v1 hash", v1.IsV2(), v1.ID(), v1.URN())
+	}
+	if !v2.IsV2() || v2.ID() != v2Hash[:40] || v2.URN() != "urn:btmh:1220"+v2Hash {
+		t.Fatalf("got v2 %v, ID %q and URN %q for a v2 hash", v2.IsV2(), v2.ID(), v2.URN())
+	}
+}
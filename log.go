@@ -1,9 +1,11 @@
 package qbittorrent
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 /*
@@ -20,6 +22,11 @@ import (
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-log
 */
 func (c *Client) GetLog(params *GetLogParams) (results []GetLogResponse, err error) {
+	return c.GetLogCtx(context.Background(), params)
+}
+
+// GetLogCtx is like [Client.GetLog] but uses ctx for the underlying requests.
+func (c *Client) GetLogCtx(ctx context.Context, params *GetLogParams) (results []GetLogResponse, err error) {
 
 	defaultLog := true
 	if params == nil {
@@ -49,7 +56,7 @@ func (c *Client) GetLog(params *GetLogParams) (results []GetLogResponse, err err
 	queryParams.Add("critical", strconv.FormatBool(*params.Critical))
 	queryParams.Add("last_known_id", strconv.Itoa(*params.LastKnownId))
 
-	body, err := c.getReq("/api/v2/log/main", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/log/main", &queryParams)
 	if err != nil {
 		return
 	}
@@ -72,11 +79,16 @@ func (c *Client) GetLog(params *GetLogParams) (results []GetLogResponse, err err
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-peer-log
 */
 func (c *Client) GetPeerLog(lastKnownId int) (results []GetPeerLogResponse, err error) {
+	return c.GetPeerLogCtx(context.Background(), lastKnownId)
+}
+
+// GetPeerLogCtx is like [Client.GetPeerLog] but uses ctx for the underlying requests.
+func (c *Client) GetPeerLogCtx(ctx context.Context, lastKnownId int) (results []GetPeerLogResponse, err error) {
 
 	queryParams := url.Values{}
 	queryParams.Add("last_known_id", strconv.Itoa(lastKnownId))
 
-	body, err := c.getReq("/api/v2/log/peers", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/log/peers", &queryParams)
 	if err != nil {
 		return
 	}
@@ -88,3 +100,135 @@ func (c *Client) GetPeerLog(lastKnownId int) (results []GetPeerLogResponse, err
 
 	return
 }
+
+/*
+FollowLog polls the main log and sends every new message in order, like `tail -f`.
+
+It keeps track of the last message id, and starts over from the beginning of the log when the server
+was restarted (message ids reset). Messages are filtered by type using the flags of "params", and
+"params.LastKnownId" can be used to skip older messages.
+
+Both channels are closed when ctx is done or when a request fails, in which case the error is sent on the
+error channel first. Cancelling ctx is not reported as an error.
+
+# Example
+
+	warning := false
+	messages, errs := client.FollowLog(ctx, &qbittorrent.GetLogParams{Normal: &warning})
+	for msg := range messages {
+	 fmt.Println(msg.Id, msg.Message)
+	}
+	if err := <-errs; err != nil {
+	 panic(err)
+	}
+*/
+func (c *Client) FollowLog(ctx context.Context, params *GetLogParams) (<-chan GetLogResponse, <-chan error) {
+	p := GetLogParams{}
+	if params != nil {
+		p = *params
+	}
+
+	lastKnownId := -1
+	if p.LastKnownId != nil {
+		lastKnownId = *p.LastKnownId
+	}
+
+	var types LogMessageType
+	for _, t := range []struct {
+		include *bool
+		msgType LogMessageType
+	}{{p.Normal, LogNormal}, {p.Info, LogInfo}, {p.Warning, LogWarn}, {p.Critical, LogCritical}} {
+		if t.include == nil || *t.include {
+			types |= t.msgType
+		}
+	}
+
+	fetch := func(ctx context.Context, id int) (results []GetLogResponse, err error) {
+		p.LastKnownId = &id
+		results, err = c.GetLogCtx(ctx, &p)
+		if err != nil {
+			return
+		}
+
+		filtered := results[:0]
+		for _, msg := range results {
+			if msg.Type&types != 0 {
+				filtered = append(filtered, msg)
+			}
+		}
+		return filtered, nil
+	}
+
+	return followLog(ctx, lastKnownId, fetch, func(msg GetLogResponse) int { return msg.Id })
+}
+
+/*
+FollowPeerLog polls the peer log and sends every new entry in order, like `tail -f`.
+
+It behaves like [Client.FollowLog], starting from the beginning of the peer log.
+*/
+func (c *Client) FollowPeerLog(ctx context.Context) (<-chan GetPeerLogResponse, <-chan error) {
+	return followLog(ctx, -1, c.GetPeerLogCtx, func(entry GetPeerLogResponse) int { return entry.Id })
+}
+
+// followLog implements the polling of FollowLog and FollowPeerLog.
+//
+// To notice server restarts the last delivered entry is requested again, it's always part of the
+// response unless the ids were reset.
+func followLog[T comparable](ctx context.Context, lastKnownId int, fetch func(context.Context, int) ([]T, error), id func(T) int) (<-chan T, <-chan error) {
+	entries := make(chan T)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(entries)
+
+		var last *T
+
+		for {
+			var results []T
+			var err error
+
+			if last == nil {
+				results, err = fetch(ctx, lastKnownId)
+			} else {
+				results, err = fetch(ctx, id(*last)-1)
+				if err == nil {
+					if len(results) > 0 && results[0] == *last {
+						results = results[1:]
+					} else {
+						// the server was restarted
+						last, lastKnownId = nil, -1
+						results, err = fetch(ctx, lastKnownId)
+					}
+				}
+			}
+
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+
+			for i := range results {
+				select {
+				case <-ctx.Done():
+					return
+				case entries <- results[i]:
+				}
+				last = &results[i]
+			}
+
+			timer := time.NewTimer(defaultPollInterval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+
+	return entries, errs
+}
@@ -0,0 +1,115 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+// receive reads n entries from a followed log
+func receive[T any](t *testing.T, entries <-chan T, n int) (results []T) {
+	t.Helper()
+
+	for range n {
+		select {
+		case entry, ok := <-entries:
+			if !ok {
+				t.Fatalf("the channel was closed after %d entries, want %d", len(results), n)
+			}
+			results = append(results, entry)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %d entries, want %d", len(results), n)
+		}
+	}
+	return
+}
+
+func logMessages(entries []qbittorrent.GetLogResponse) (messages []string) {
+	for _, entry := range entries {
+		messages = append(messages, entry.Message)
+	}
+	return
+}
+
+func TestFollowLog(t *testing.T) {
+	srv, client := newTestClient(t)
+	srv.AddLog(qbittorrent.LogNormal, "started")
+	srv.AddLog(qbittorrent.LogInfo, "hidden")
+	srv.AddLog(qbittorrent.LogWarn, "port in use")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hidden := false
+	messages, errs := client.FollowLog(ctx, &qbittorrent.GetLogParams{Info: &hidden})
+
+	if got := logMessages(receive(t, messages, 2)); !reflect.DeepEqual(got, []string{"started", "port in use"}) {
+		t.Fatalf("got %v", got)
+	}
+
+	// the ids start over after a restart, the new log is followed from its beginning
+	srv.ClearLogs()
+	srv.AddLog(qbittorrent.LogNormal, "restarted")
+	srv.AddLog(qbittorrent.LogCritical, "disk full")
+
+	if got := logMessages(receive(t, messages, 2)); !reflect.DeepEqual(got, []string{"restarted", "disk full"}) {
+		t.Fatalf("got %v after a restart", got)
+	}
+
+	cancel()
+	for range messages {
+	}
+	if err, ok := <-errs; ok || err != nil {
+		t.Fatalf("got error %v after cancelling", err)
+	}
+}
+
+func TestFollowPeerLog(t *testing.T) {
+	srv, client := newTestClient(t)
+	srv.AddPeerLog("10.0.0.1", true, "IP filter")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	entries, errs := client.FollowPeerLog(ctx)
+
+	if got := receive(t, entries, 1); got[0].Ip != "10.0.0.1" || got[0].Id != 0 {
+		t.Fatalf("got %+v", got)
+	}
+
+	srv.AddPeerLog("10.0.0.2", true, "port filter")
+	if got := receive(t, entries, 1); got[0].Ip != "10.0.0.2" || got[0].Id != 1 {
+		t.Fatalf("got %+v, want only the new entry", got)
+	}
+
+	srv.ClearLogs()
+	srv.AddPeerLog("10.0.0.3", false, "")
+	srv.AddPeerLog("10.0.0.4", false, "")
+	if got := receive(t, entries, 2); got[0].Ip != "10.0.0.3" || got[1].Ip != "10.0.0.4" {
+		t.Fatalf("got %+v after a restart", got)
+	}
+
+	// cancelling closes the channels even when nobody reads the entries
+	srv.AddPeerLog("10.0.0.5", false, "")
+	srv.AddPeerLog("10.0.0.6", false, "")
+	waitRequests(t, srv, "/api/v2/log/peers", srv.Requests("/api/v2/log/peers")+1)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range entries {
+		}
+		for range errs {
+			t.Error("got an error after cancelling")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the channels were not closed")
+	}
+}
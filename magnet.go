@@ -0,0 +1,192 @@
+package qbittorrent
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxSelectOnly limits the number of file indexes of the so parameter, as a range like 0-999999999 would use a lot of memory
+const maxSelectOnly = 1 << 16
+
+/*
+Magnet is a magnet link, create it with [ParseMagnet] or fill the fields and call [Magnet.String].
+
+# Example
+
+	magnet := &qbittorrent.Magnet{
+	 InfoHashV1: hash,
+	 Name:       "ubuntu",
+	 Trackers:   []string{"udp://tracker.example.com:1337"},
+	}
+
+	torrent := qbittorrent.NewTorrent().AddMagnet(magnet)
+*/
+type Magnet struct {
+	InfoHashV1 InfoHash // v1 info hash (xt=urn:btih:), empty for a v2 only torrent
+	InfoHashV2 InfoHash // v2 info hash (xt=urn:btmh:), empty for a v1 only torrent
+	Name       string   // Display name (dn)
+	Length     int64    // Size of the torrent in bytes (xl), 0 when unknown
+	Trackers   []string // Tracker URLs (tr)
+	WebSeeds   []string // Web seed URLs (ws)
+	SelectOnly []int    // Indexes of the files to download (so, BEP 53), empty to download every file
+}
+
+/*
+ParseMagnet parses a magnet link, it needs at least a v1 or v2 info hash.
+
+Unknown parameters are ignored, an invalid link returns an error matching [ErrInvalidMagnet].
+*/
+func ParseMagnet(link string) (magnet *Magnet, err error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme != "magnet" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidMagnet, link)
+	}
+
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMagnet, err)
+	}
+
+	magnet = &Magnet{
+		Name:     query.Get("dn"),
+		Trackers: query["tr"],
+		WebSeeds: query["ws"],
+	}
+
+	for _, xt := range query["xt"] {
+		if !strings.HasPrefix(xt, "urn:btih:") && !strings.HasPrefix(xt, "urn:btmh:") {
+			continue
+		}
+
+		hash, err := ParseInfoHash(xt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidMagnet, err)
+		}
+		if hash.IsV2() {
+			magnet.InfoHashV2 = hash
+		} else {
+			magnet.InfoHashV1 = hash
+		}
+	}
+	if magnet.InfoHashV1 == "" && magnet.InfoHashV2 == "" {
+		return nil, fmt.Errorf("%w: missing info hash", ErrInvalidMagnet)
+	}
+
+	if xl := query.Get("xl"); xl != "" {
+		magnet.Length, err = strconv.ParseInt(xl, 10, 64)
+		if err != nil || magnet.Length < 0 {
+			return nil, fmt.Errorf("%w: invalid length %q", ErrInvalidMagnet, xl)
+		}
+	}
+
+	if so := query.Get("so"); so != "" {
+		magnet.SelectOnly, err = parseSelectOnly(so)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+// parseSelectOnly parses a list of file indexes and ranges, e.g. "0,2,4-6"
+func parseSelectOnly(so string) (indexes []int, err error) {
+	for _, item := range strings.Split(so, ",") {
+		first, last, isRange := strings.Cut(item, "-")
+
+		start, err := strconv.Atoi(first)
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("%w: invalid file index %q", ErrInvalidMagnet, item)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(last)
+			if err != nil || end < start {
+				return nil, fmt.Errorf("%w: invalid file range %q", ErrInvalidMagnet, item)
+			}
+		}
+
+		// end-start can't overflow as both are positive, unlike len(indexes)+end-start
+		if end-start >= maxSelectOnly-len(indexes) {
+			return nil, fmt.Errorf("%w: too many file indexes", ErrInvalidMagnet)
+		}
+		for i := range end - start + 1 {
+			indexes = append(indexes, start+i)
+		}
+	}
+
+	return
+}
+
+// Hash returns the hash identifying the torrent in qBittorrent: the v1 info hash, or the v2 info hash truncated
+// to 20 bytes for a v2 only torrent.
+func (m *Magnet) Hash() string {
+	if m.InfoHashV1 != "" {
+		return m.InfoHashV1.ID()
+	}
+	return m.InfoHashV2.ID()
+}
+
+// String builds the magnet link, it can be passed to [NewTorrentOptions.AddUrl].
+func (m *Magnet) String() string {
+	var params []string
+	add := func(key, value string) {
+		params = append(params, key+"="+url.QueryEscape(value))
+	}
+
+	// the hashes are not escaped so the link stays readable
+	if m.InfoHashV1 != "" {
+		params = append(params, "xt="+m.InfoHashV1.URN())
+	}
+	if m.InfoHashV2 != "" {
+		params = append(params, "xt="+m.InfoHashV2.URN())
+	}
+	if m.Name != "" {
+		add("dn", m.Name)
+	}
+	if m.Length > 0 {
+		add("xl", strconv.FormatInt(m.Length, 10))
+	}
+	for _, tracker := range m.Trackers {
+		add("tr", tracker)
+	}
+	for _, webSeed := range m.WebSeeds {
+		add("ws", webSeed)
+	}
+	if len(m.SelectOnly) > 0 {
+		params = append(params, "so="+formatSelectOnly(m.SelectOnly))
+	}
+
+	return "magnet:?" + strings.Join(params, "&")
+}
+
+// formatSelectOnly joins file indexes, with consecutive indexes as ranges
+func formatSelectOnly(indexes []int) string {
+	sorted := append([]int{}, indexes...)
+	sort.Ints(sorted)
+
+	var items []string
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] <= sorted[j]+1 {
+			j++
+		}
+
+		if sorted[i] == sorted[j] {
+			items = append(items, strconv.Itoa(sorted[i]))
+		} else {
+			items = append(items, strconv.Itoa(sorted[i])+"-"+strconv.Itoa(sorted[j]))
+		}
+		i = j + 1
+	}
+
+	return strings.Join(items, ",")
+}
+
+// Magnet parses the magnet link of the torrent, see [ParseMagnet].
+func (t *TorrentListResponse) Magnet() (magnet *Magnet, err error) {
+	return ParseMagnet(t.MagnetURI)
+}
@@ -0,0 +1,144 @@
+package qbittorrent_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+func TestParseMagnet(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want *qbittorrent.Magnet
+	}{
+		{"v1", ubuntuMagnet, &qbittorrent.Magnet{InfoHashV1: ubuntuHash, Name: "ubuntu"}},
+		{"base32", "magnet:?xt=urn:btih:" + ubuntuHashBase32, &qbittorrent.Magnet{InfoHashV1: ubuntuHash}},
+		{"v2", "magnet:?xt=urn:btmh:1220" + v2Hash, &qbittorrent.Magnet{InfoHashV2: v2Hash}},
+		{"hybrid", "magnet:?xt=urn:btih:" + ubuntuHash + "&xt=urn:btmh:1220" + v2Hash,
+			&qbittorrent.Magnet{InfoHashV1: ubuntuHash, InfoHashV2: v2Hash}},
+		{
+			"every parameter",
+			"magnet:?xt=urn:btih:" + ubuntuHash + "&dn=ubuntu+24.04&xl=6114656256&tr=udp%3A%2F%2Fa.example.com%3A1337&tr=udp%3A%2F%2Fb.example.com%3A1337" +
+				"&ws=https%3A%2F%2Fmirror.example.com%2Fubuntu.iso&so=0,2,4-6&x.pe=10.0.0.1:6881",
+			&qbittorrent.Magnet{
+				InfoHashV1: ubuntuHash,
+				Name:       "ubuntu 24.04",
+				Length:     6114656256,
+				Trackers:   []string{"udp://a.example.com:1337", "udp://b.example.com:1337"},
+				WebSeeds:   []string{"https://mirror.example.com/ubuntu.iso"},
+				SelectOnly: []int{0, 2, 4, 5, 6},
+			},
+		},
+		{"other xt ignored", "magnet:?xt=urn:sha1:abc&xt=urn:btih:" + ubuntuHash, &qbittorrent.Magnet{InfoHashV1: ubuntuHash}},
+		{"largest file index", "magnet:?xt=urn:btih:" + ubuntuHash + "&so=9223372036854775807",
+			&qbittorrent.Magnet{InfoHashV1: ubuntuHash, SelectOnly: []int{9223372036854775807}}},
+
+		{"not a magnet link", "https://example.com/ubuntu.torrent", nil},
+		{"missing info hash", "magnet:?dn=ubuntu", nil},
+		{"invalid info hash", "magnet:?xt=urn:btih:1234", nil},
+		{"invalid query", "magnet:?xt=%zz", nil},
+		{"negative length", ubuntuMagnet + "&xl=-1", nil},
+		{"invalid length", ubuntuMagnet + "&xl=big", nil},
+		{"invalid file index", ubuntuMagnet + "&so=a", nil},
+		{"negative file index", ubuntuMagnet + "&so=-1", nil},
+		{"reversed file range", ubuntuMagnet + "&so=6-4", nil},
+		{"too many file indexes", ubuntuMagnet + "&so=0-65536", nil},
+		{"too many file indexes in ranges", ubuntuMagnet + "&so=0-40000,50000-90000", nil},
+		{"huge file range", ubuntuMagnet + "&so=0,0-9223372036854775807", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			var got *qbittorrent.Magnet
+			var err error
+			go func() {
+				defer close(done)
+				got, err = qbittorrent.ParseMagnet(tt.link)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("ParseMagnet didn't return")
+			}
+
+			if tt.want == nil {
+				if !errors.Is(err, qbittorrent.ErrInvalidMagnet) {
+					t.Fatalf("got %+v, %v, want ErrInvalidMagnet", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMagnetString(t *testing.T) {
+	tests := []struct {
+		name   string
+		magnet *qbittorrent.Magnet
+		want   string
+	}{
+		{"v1", &qbittorrent.Magnet{InfoHashV1: ubuntuHash}, "magnet:?xt=urn:btih:" + ubuntuHash},
+		{"v2", &qbittorrent.Magnet{InfoHashV2: v2Hash}, "magnet:?xt=urn:btmh:1220" + v2Hash},
+		{
+			"every field",
+			&qbittorrent.Magnet{
+				InfoHashV1: ubuntuHash,
+				InfoHashV2: v2Hash,
+				Name:       "ubuntu 24.04",
+				Length:     1 << 30,
+				Trackers:   []string{"udp://a.example.com:1337"},
+				WebSeeds:   []string{"https://mirror.example.com/ubuntu.iso"},
+				SelectOnly: []int{6, 0, 4, 5, 2, 2},
+			},
+			"magnet:?xt=urn:btih:" + ubuntuHash + "&xt=urn:btmh:1220" + v2Hash + "&dn=ubuntu+24.04&xl=1073741824" +
+				"&tr=udp%3A%2F%2Fa.example.com%3A1337&ws=https%3A%2F%2Fmirror.example.com%2Fubuntu.iso&so=0,2,4-6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.magnet.String()
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+
+			// the link parses back to the same magnet, with sorted file indexes
+			parsed, err := qbittorrent.ParseMagnet(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.String() != got {
+				t.Fatalf("got %q after parsing, want %q", parsed.String(), got)
+			}
+		})
+	}
+}
+
+func TestMagnetHash(t *testing.T) {
+	tests := []struct {
+		magnet *qbittorrent.Magnet
+		want   string
+	}{
+		{&qbittorrent.Magnet{InfoHashV1: ubuntuHash}, ubuntuHash},
+		{&qbittorrent.Magnet{InfoHashV1: ubuntuHash, InfoHashV2: v2Hash}, ubuntuHash},
+		{&qbittorrent.Magnet{InfoHashV2: v2Hash}, v2Hash[:40]},
+	}
+
+	for _, tt := range tests {
+		if got := tt.magnet.Hash(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.magnet, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package metainfo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// maxDepth limits the nesting of lists and dictionaries, so a malicious file can't exhaust the stack
+const maxDepth = 256
+
+// SyntaxError is returned when the data is not valid bencode.
+type SyntaxError struct {
+	Offset int    // Offset of the invalid byte in the data
+	msg    string // Description of the error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("metainfo: invalid bencode at offset %d: %s", e.Offset, e.msg)
+}
+
+/*
+Decode decodes bencoded data, the values are decoded as:
+  - integers as int64
+  - byte strings as string
+  - lists as []any
+  - dictionaries as map[string]any
+
+The decoder is strict, it only accepts the canonical encoding of a value: integers without leading zeros or "-0",
+dictionary keys sorted and unique, and no data after the value. So encoding the decoded value with [Encode]
+returns the same bytes.
+
+Invalid data is reported as a [*SyntaxError].
+*/
+func Decode(data []byte) (value any, err error) {
+	d := &decoder{data: data}
+	return d.decode()
+}
+
+type decoder struct {
+	data  []byte
+	pos   int
+	depth int
+	info  []byte // raw info dictionary of the top level dictionary, kept to compute the info hashes
+}
+
+func (d *decoder) decode() (value any, err error) {
+	value, err = d.value()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.pos != len(d.data) {
+		return nil, d.errorf("unexpected data after the value")
+	}
+
+	return
+}
+
+func (d *decoder) errorf(format string, args ...any) error {
+	return &SyntaxError{Offset: d.pos, msg: fmt.Sprintf(format, args...)}
+}
+
+func (d *decoder) value() (any, error) {
+	if d.pos >= len(d.data) {
+		return nil, d.errorf("unexpected end of data")
+	}
+
+	switch c := d.data[d.pos]; {
+	case c == 'i':
+		return d.integer()
+	case c >= '0' && c <= '9':
+		return d.string()
+	case c == 'l':
+		return d.list()
+	case c == 'd':
+		return d.dict()
+	default:
+		return nil, d.errorf("invalid character %q", c)
+	}
+}
+
+func (d *decoder) integer() (int64, error) {
+	start := d.pos + 1
+	end := bytes.IndexByte(d.data[start:], 'e')
+	if end < 0 {
+		d.pos = len(d.data)
+		return 0, d.errorf("unterminated integer")
+	}
+	digits := string(d.data[start : start+end])
+
+	unsigned := digits
+	if len(unsigned) > 0 && unsigned[0] == '-' {
+		unsigned = unsigned[1:]
+		if unsigned == "0" {
+			return 0, d.errorf("negative zero")
+		}
+	}
+	if !isDigits(unsigned) {
+		return 0, d.errorf("invalid integer %q", digits)
+	}
+	if len(unsigned) > 1 && unsigned[0] == '0' {
+		return 0, d.errorf("integer with leading zero %q", digits)
+	}
+
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, d.errorf("integer out of range %q", digits)
+	}
+
+	d.pos = start + end + 1
+	return n, nil
+}
+
+func (d *decoder) string() (string, error) {
+	colon := bytes.IndexByte(d.data[d.pos:], ':')
+	if colon < 0 {
+		return "", d.errorf("missing string length separator")
+	}
+	digits := string(d.data[d.pos : d.pos+colon])
+
+	if !isDigits(digits) {
+		return "", d.errorf("invalid string length %q", digits)
+	}
+	if len(digits) > 1 && digits[0] == '0' {
+		return "", d.errorf("string length with leading zero %q", digits)
+	}
+
+	n, err := strconv.ParseInt(digits, 10, 64)
+	start := d.pos + colon + 1
+	if err != nil || n > int64(len(d.data)-start) {
+		return "", d.errorf("string length %s exceeds the data", digits)
+	}
+
+	d.pos = start + int(n)
+	return string(d.data[start:d.pos]), nil
+}
+
+func (d *decoder) list() (list []any, err error) {
+	err = d.enter()
+	if err != nil {
+		return
+	}
+
+	list = []any{}
+	for {
+		if d.pos >= len(d.data) {
+			return nil, d.errorf("unterminated list")
+		}
+		if d.data[d.pos] == 'e' {
+			break
+		}
+
+		value, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+
+	d.leave()
+	return
+}
+
+func (d *decoder) dict() (dict map[string]any, err error) {
+	err = d.enter()
+	if err != nil {
+		return
+	}
+
+	dict = map[string]any{}
+	first := true
+	var previous string
+	for {
+		if d.pos >= len(d.data) {
+			return nil, d.errorf("unterminated dictionary")
+		}
+		if d.data[d.pos] == 'e' {
+			break
+		}
+
+		if c := d.data[d.pos]; c < '0' || c > '9' {
+			return nil, d.errorf("dictionary key is not a string")
+		}
+		keyPos := d.pos
+		key, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		if !first && key <= previous {
+			d.pos = keyPos
+			return nil, d.errorf("dictionary key %q is not sorted or duplicated", key)
+		}
+		first, previous = false, key
+
+		start := d.pos
+		value, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		dict[key] = value
+
+		if d.depth == 1 && key == "info" {
+			d.info = d.data[start:d.pos]
+		}
+	}
+
+	d.leave()
+	return
+}
+
+// enter skips the first byte of a list or dictionary
+func (d *decoder) enter() error {
+	if d.depth >= maxDepth {
+		return d.errorf("nested too deeply")
+	}
+	d.depth++
+	d.pos++
+	return nil
+}
+
+// leave skips the "e" ending a list or dictionary
+func (d *decoder) leave() {
+	d.depth--
+	d.pos++
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Encode bencodes a value, the supported types are:
+  - int, int64 and bool, encoded as integers (1 for true)
+  - string and []byte, encoded as byte strings
+  - []any, []string and [][]string, encoded as lists
+  - map[string]any, encoded as a dictionary with sorted keys
+*/
+func Encode(value any) (data []byte, err error) {
+	var buf bytes.Buffer
+	err = encode(&buf, value, 0)
+	if err != nil {
+		return
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encode(buf *bytes.Buffer, value any, depth int) (err error) {
+	if depth > maxDepth {
+		return errors.New("metainfo: value nested too deeply")
+	}
+
+	switch v := value.(type) {
+	case int:
+		encodeInt(buf, int64(v))
+	case int64:
+		encodeInt(buf, v)
+	case bool:
+		if v {
+			encodeInt(buf, 1)
+		} else {
+			encodeInt(buf, 0)
+		}
+	case string:
+		encodeString(buf, v)
+	case []byte:
+		encodeString(buf, string(v))
+	case []string:
+		buf.WriteByte('l')
+		for _, s := range v {
+			encodeString(buf, s)
+		}
+		buf.WriteByte('e')
+	case [][]string:
+		buf.WriteByte('l')
+		for _, list := range v {
+			err = encode(buf, list, depth+1)
+			if err != nil {
+				return
+			}
+		}
+		buf.WriteByte('e')
+	case []any:
+		buf.WriteByte('l')
+		for _, item := range v {
+			err = encode(buf, item, depth+1)
+			if err != nil {
+				return
+			}
+		}
+		buf.WriteByte('e')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('d')
+		for _, key := range keys {
+			encodeString(buf, key)
+			err = encode(buf, v[key], depth+1)
+			if err != nil {
+				return
+			}
+		}
+		buf.WriteByte('e')
+	default:
+		return fmt.Errorf("metainfo: can't encode a value of type %T", value)
+	}
+
+	return
+}
+
+func encodeInt(buf *bytes.Buffer, n int64) {
+	buf.WriteByte('i')
+	buf.WriteString(strconv.FormatInt(n, 10))
+	buf.WriteByte('e')
+}
+
+func encodeString(buf *bytes.Buffer, s string) {
+	buf.WriteString(strconv.Itoa(len(s)))
+	buf.WriteByte(':')
+	buf.WriteString(s)
+}
@@ -0,0 +1,120 @@
+package metainfo
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		data string
+		want any
+	}{
+		{"i0e", int64(0)},
+		{"i-42e", int64(-42)},
+		{"i9223372036854775807e", int64(9223372036854775807)},
+		{"0:", ""},
+		{"4:spam", "spam"},
+		{"le", []any{}},
+		{"l4:spami42ee", []any{"spam", int64(42)}},
+		{"de", map[string]any{}},
+		{"d3:cow3:moo4:spaml1:a1:bee", map[string]any{"cow": "moo", "spam": []any{"a", "b"}}},
+	}
+
+	for _, test := range tests {
+		got, err := Decode([]byte(test.data))
+		if err != nil {
+			t.Errorf("%q: %v", test.data, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %#v, want %#v", test.data, got, test.want)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"x",
+		"i01e",                   // leading zero
+		"i-0e",                   // negative zero
+		"ie",                     // no digits
+		"i-e",                    // no digits
+		"i1",                     // unterminated
+		"i99999999999999999999e", // overflow
+		"01:a",                   // leading zero in a length
+		"4:abc",                  // string longer than the data
+		"l",                      // unterminated list
+		"d1:a",                   // missing value
+		"di1e1:ae",               // key is not a string
+		"d1:b0:1:a0:e",           // unsorted keys
+		"d1:a0:1:a0:e",           // duplicate keys
+		"i1ei2e",                 // data after the value
+		strings.Repeat("l", maxDepth+1) + strings.Repeat("e", maxDepth+1), // nested too deeply
+	}
+
+	for _, data := range invalid {
+		_, err := Decode([]byte(data))
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("%q: got error %v, want a *SyntaxError", data, err)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	data, err := Encode(map[string]any{
+		"b":      []string{"x", "y"},
+		"a":      int64(-1),
+		"tiers":  [][]string{{"t1"}, {"t2", "t3"}},
+		"bytes":  []byte("raw"),
+		"flag":   true,
+		"nested": []any{map[string]any{"z": 0}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "d1:ai-1e1:bl1:x1:ye5:bytes3:raw4:flagi1e6:nestedld1:zi0eee5:tiersll2:t1el2:t22:t3eee"
+	if string(data) != want {
+		t.Fatalf("got %q, want %q", data, want)
+	}
+
+	if _, err := Encode(map[string]any{"float": 1.5}); err == nil {
+		t.Fatal("encoded a float")
+	}
+}
+
+// FuzzDecode checks that the decoder never panics, and that a decoded value is encoded to the same bytes, as
+// the decoder only accepts the canonical encoding.
+func FuzzDecode(f *testing.F) {
+	for _, seed := range []string{"i0e", "i-1e", "4:spam", "le", "de", "d3:cow3:moo4:spam4:eggse", "l4:spami42ee", "d1:ad1:bl1:ceee"} {
+		f.Add([]byte(seed))
+	}
+	for _, torrent := range testTorrents {
+		f.Add([]byte(torrent.data))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value, err := Decode(data)
+		if err != nil {
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("got error %v, want a *SyntaxError", err)
+			}
+			return
+		}
+
+		encoded, err := Encode(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("decoded %q and encoded it as %q", data, encoded)
+		}
+	})
+}
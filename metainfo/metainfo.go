@@ -0,0 +1,581 @@
+/*
+Package metainfo reads .torrent files, so they can be inspected before adding them with the qbittorrent package.
+
+It supports BitTorrent v1 (BEP 3), v2 (BEP 52) and hybrid torrents, and contains the strict bencode decoder and
+encoder used to read them.
+
+# Example
+
+	meta, err := metainfo.ReadFile("ubuntu.torrent")
+	if err != nil {
+	 panic(err)
+	}
+
+	fmt.Println(meta.Info.Name, meta.Info.TotalLength(), meta.Hash())
+*/
+package metainfo
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidMetaInfo is returned when a .torrent file is valid bencode but not a valid torrent, e.g. a required key is missing.
+var ErrInvalidMetaInfo = errors.New("metainfo: invalid torrent")
+
+// MetaInfo is the content of a .torrent file.
+type MetaInfo struct {
+	Announce     string            // Tracker URL
+	AnnounceList [][]string        // Tiers of tracker URLs (BEP 12), used instead of Announce when not empty
+	URLList      []string          // Web seed URLs (BEP 19)
+	Comment      string            // Torrent comment
+	CreatedBy    string            // Program that created the torrent
+	CreationDate time.Time         // Zero when unknown
+	Info         Info              // The info dictionary
+	PieceLayers  map[string][]byte // v2 piece hashes of the files larger than a piece, keyed by the pieces root of the file
+	InfoBytes    []byte            // The bencoded info dictionary, as found in the file
+	HashV1       string            // Hex SHA-1 of the info dictionary, empty for a v2 only torrent
+	HashV2       string            // Hex SHA-256 of the info dictionary, empty for a v1 only torrent
+}
+
+// Info is the info dictionary of a torrent, the part identified by the info hash.
+type Info struct {
+	Name        string // Suggested name of the file, or of the directory for a multi-file torrent
+	PieceLength int64  // Number of bytes in each piece
+	Pieces      []byte // Concatenated SHA-1 hashes of the v1 pieces, empty for a v2 only torrent
+	Private     bool   // True if the torrent is from a private tracker (BEP 27)
+	Source      string // Source tag, used by private trackers
+	MetaVersion int    // 2 for v2 and hybrid torrents, 1 otherwise
+	SingleFile  bool   // True if the torrent contains a single file instead of a directory
+	Files       []File // Files of the torrent in order, including the v1 padding files
+}
+
+// File is a file of a torrent.
+type File struct {
+	Path       []string // Path components relative to the torrent directory, the name of the torrent for a single file torrent
+	Length     int64    // Size in bytes
+	Attr       string   // File attributes (BEP 47), e.g. "p" for a padding file
+	PiecesRoot []byte   // v2 merkle root of the file, empty for a v1 only torrent and for empty files
+}
+
+// IsPadding returns true if the file is a v1 padding file inserted to align the next file to a piece.
+func (f File) IsPadding() bool {
+	return strings.Contains(f.Attr, "p")
+}
+
+// TotalLength returns the size of the torrent in bytes, without the padding files.
+func (i *Info) TotalLength() (length int64) {
+	for _, file := range i.Files {
+		if !file.IsPadding() {
+			length += file.Length
+		}
+	}
+	return
+}
+
+// NumPieces returns the number of v1 pieces, 0 for a v2 only torrent.
+func (i *Info) NumPieces() int {
+	return len(i.Pieces) / sha1.Size
+}
+
+// IsV1 returns true if the torrent has a v1 info hash, which is the case of hybrid torrents.
+func (m *MetaInfo) IsV1() bool {
+	return m.HashV1 != ""
+}
+
+// IsV2 returns true if the torrent has a v2 info hash, which is the case of hybrid torrents.
+func (m *MetaInfo) IsV2() bool {
+	return m.HashV2 != ""
+}
+
+// IsHybrid returns true if the torrent has both v1 and v2 info hashes.
+func (m *MetaInfo) IsHybrid() bool {
+	return m.IsV1() && m.IsV2()
+}
+
+// Hash returns the hash identifying the torrent in qBittorrent: the v1 info hash, or the v2 info hash truncated
+// to 20 bytes for a v2 only torrent. It's empty when m has no info hash, e.g. when it was not created by [Parse].
+func (m *MetaInfo) Hash() string {
+	switch {
+	case m.IsV1():
+		return m.HashV1
+	case len(m.HashV2) >= 2*sha1.Size:
+		return m.HashV2[:2*sha1.Size]
+	}
+	return ""
+}
+
+// Trackers returns the tracker URLs, from AnnounceList when it's not empty and from Announce otherwise.
+func (m *MetaInfo) Trackers() (trackers []string) {
+	for _, tier := range m.AnnounceList {
+		trackers = append(trackers, tier...)
+	}
+	if len(trackers) == 0 && m.Announce != "" {
+		trackers = append(trackers, m.Announce)
+	}
+	return
+}
+
+// ReadFile parses the .torrent file at path, see [Parse].
+func ReadFile(path string) (meta *MetaInfo, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	return Parse(data)
+}
+
+/*
+Parse parses the content of a .torrent file.
+
+Data that is not valid bencode is reported as a [*SyntaxError], and a torrent with missing or invalid keys
+matches [ErrInvalidMetaInfo]. File paths are checked so they can't escape the torrent directory.
+*/
+func Parse(data []byte) (meta *MetaInfo, err error) {
+	d := &decoder{data: data}
+	value, err := d.decode()
+	if err != nil {
+		return
+	}
+
+	root, ok := value.(map[string]any)
+	if !ok {
+		return nil, invalidf("not a dictionary")
+	}
+
+	infoDict, ok := root["info"].(map[string]any)
+	if !ok {
+		return nil, invalidf("missing info dictionary")
+	}
+
+	meta = &MetaInfo{InfoBytes: d.info}
+
+	meta.Info, err = parseInfo(infoDict)
+	if err != nil {
+		return nil, err
+	}
+
+	err = meta.parseRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := infoDict["pieces"]; ok {
+		sum := sha1.Sum(d.info)
+		meta.HashV1 = hex.EncodeToString(sum[:])
+	}
+	if meta.Info.MetaVersion == 2 {
+		sum := sha256.Sum256(d.info)
+		meta.HashV2 = hex.EncodeToString(sum[:])
+	}
+
+	return
+}
+
+func invalidf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidMetaInfo, fmt.Sprintf(format, args...))
+}
+
+// parseRoot parses the keys of the top level dictionary, other than the info dictionary
+func (m *MetaInfo) parseRoot(root map[string]any) (err error) {
+	fields := dict(root)
+
+	m.Announce, err = fields.string("announce")
+	if err != nil {
+		return
+	}
+
+	m.Comment, err = fields.string("comment")
+	if err != nil {
+		return
+	}
+
+	m.CreatedBy, err = fields.string("created by")
+	if err != nil {
+		return
+	}
+
+	date, err := fields.int("creation date")
+	if err != nil {
+		return
+	}
+	if date > 0 {
+		m.CreationDate = time.Unix(date, 0)
+	}
+
+	if value, ok := root["announce-list"]; ok {
+		tiers, ok := value.([]any)
+		if !ok {
+			return invalidf("announce-list is not a list")
+		}
+		for _, tier := range tiers {
+			urls, err := stringList(tier)
+			if err != nil {
+				return invalidf("announce-list tier is not a list of strings")
+			}
+			if len(urls) > 0 {
+				m.AnnounceList = append(m.AnnounceList, urls)
+			}
+		}
+	}
+
+	// url-list is either a single URL or a list of URLs
+	switch value := root["url-list"].(type) {
+	case nil:
+	case string:
+		if value != "" {
+			m.URLList = []string{value}
+		}
+	default:
+		m.URLList, err = stringList(value)
+		if err != nil {
+			return invalidf("url-list is not a list of strings")
+		}
+	}
+
+	if value, ok := root["piece layers"]; ok {
+		layers, ok := value.(map[string]any)
+		if !ok {
+			return invalidf("piece layers is not a dictionary")
+		}
+		m.PieceLayers = make(map[string][]byte, len(layers))
+		for piecesRoot, value := range layers {
+			hashes, ok := value.(string)
+			if !ok || len(piecesRoot) != sha256.Size || len(hashes)%sha256.Size != 0 {
+				return invalidf("invalid piece layer")
+			}
+			m.PieceLayers[piecesRoot] = []byte(hashes)
+		}
+	}
+
+	return
+}
+
+func parseInfo(info map[string]any) (result Info, err error) {
+	fields := dict(info)
+
+	result.Name, err = fields.string("name")
+	if err != nil {
+		return
+	}
+	if !validPathComponent(result.Name) {
+		return result, invalidf("invalid name %q", result.Name)
+	}
+
+	result.PieceLength, err = fields.int("piece length")
+	if err != nil {
+		return
+	}
+	if result.PieceLength <= 0 {
+		return result, invalidf("invalid piece length %d", result.PieceLength)
+	}
+
+	private, err := fields.int("private")
+	if err != nil {
+		return
+	}
+	result.Private = private == 1
+
+	result.Source, err = fields.string("source")
+	if err != nil {
+		return
+	}
+
+	version, err := fields.int("meta version")
+	if err != nil {
+		return
+	}
+
+	pieces, err := fields.string("pieces")
+	if err != nil {
+		return
+	}
+	result.Pieces = []byte(pieces)
+
+	var v1Files, v2Files []File
+	var v1Single, v2Single bool
+
+	if _, ok := info["pieces"]; ok {
+		v1Files, v1Single, err = parseV1Files(result.Name, info)
+		if err != nil {
+			return
+		}
+
+		var length int64
+		for _, file := range v1Files {
+			length += file.Length
+		}
+		if len(pieces)%sha1.Size != 0 || int64(len(pieces)/sha1.Size) != (length+result.PieceLength-1)/result.PieceLength {
+			return result, invalidf("pieces don't match the length of the files")
+		}
+	}
+
+	switch version {
+	case 0, 1:
+		result.MetaVersion = 1
+		if _, ok := info["pieces"]; !ok {
+			return result, invalidf("missing pieces")
+		}
+	case 2:
+		result.MetaVersion = 2
+		// v2 pieces must be a power of two of at least 16 KiB
+		if result.PieceLength < 16<<10 || result.PieceLength&(result.PieceLength-1) != 0 {
+			return result, invalidf("invalid v2 piece length %d", result.PieceLength)
+		}
+
+		tree, ok := info["file tree"].(map[string]any)
+		if !ok {
+			return result, invalidf("missing file tree")
+		}
+
+		v2Files, err = parseFileTree(tree, nil, 0)
+		if err != nil {
+			return
+		}
+		if len(v2Files) == 0 {
+			return result, invalidf("empty file tree")
+		}
+
+		// a single file torrent has a file tree with only the name of the torrent
+		v2Single = len(v2Files) == 1 && len(v2Files[0].Path) == 1 && v2Files[0].Path[0] == result.Name
+	default:
+		return result, invalidf("unsupported meta version %d", version)
+	}
+
+	switch {
+	case v2Files == nil:
+		result.Files, result.SingleFile = v1Files, v1Single
+	case v1Files == nil:
+		result.Files, result.SingleFile = v2Files, v2Single
+	default:
+		// hybrid torrent, the v1 files including padding are kept with the pieces roots of the v2 files
+		if v1Single != v2Single {
+			return result, invalidf("v1 and v2 files don't match")
+		}
+		result.Files, result.SingleFile = v1Files, v1Single
+
+		i := 0
+		for j := range result.Files {
+			if result.Files[j].IsPadding() {
+				continue
+			}
+			if i >= len(v2Files) || !sameFile(result.Files[j], v2Files[i]) {
+				return result, invalidf("v1 and v2 files don't match")
+			}
+			result.Files[j].PiecesRoot = v2Files[i].PiecesRoot
+			i++
+		}
+		if i != len(v2Files) {
+			return result, invalidf("v1 and v2 files don't match")
+		}
+	}
+
+	return
+}
+
+// parseV1Files parses the files of the v1 info dictionary, a single file torrent has a length instead of files
+func parseV1Files(name string, info map[string]any) (files []File, single bool, err error) {
+	fields := dict(info)
+
+	if _, ok := info["files"]; !ok {
+		length, err := fields.int("length")
+		if err != nil {
+			return nil, false, err
+		}
+		if _, ok := info["length"]; !ok || length < 0 {
+			return nil, false, invalidf("missing length")
+		}
+		return []File{{Path: []string{name}, Length: length}}, true, nil
+	}
+
+	list, ok := info["files"].([]any)
+	if !ok || len(list) == 0 {
+		return nil, false, invalidf("files is not a list of files")
+	}
+
+	for _, item := range list {
+		entry, ok := item.(map[string]any)
+		if !ok {
+			return nil, false, invalidf("file is not a dictionary")
+		}
+		fields := dict(entry)
+
+		var file File
+		file.Length, err = fields.int("length")
+		if err != nil {
+			return
+		}
+		if _, ok := entry["length"]; !ok || file.Length < 0 {
+			return nil, false, invalidf("missing file length")
+		}
+
+		file.Attr, err = fields.string("attr")
+		if err != nil {
+			return
+		}
+
+		file.Path, err = stringList(entry["path"])
+		if err != nil || len(file.Path) == 0 {
+			return nil, false, invalidf("invalid file path")
+		}
+		for _, component := range file.Path {
+			if !validPathComponent(component) {
+				return nil, false, invalidf("invalid file path %q", file.Path)
+			}
+		}
+
+		files = append(files, file)
+	}
+
+	return
+}
+
+// parseFileTree flattens a v2 file tree, the files are in the order of the sorted path components
+func parseFileTree(tree map[string]any, dir []string, depth int) (files []File, err error) {
+	if depth >= maxDepth {
+		return nil, invalidf("file tree nested too deeply")
+	}
+
+	names := make([]string, 0, len(tree))
+	for name := range tree {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !validPathComponent(name) {
+			return nil, invalidf("invalid file name %q", name)
+		}
+
+		node, ok := tree[name].(map[string]any)
+		if !ok {
+			return nil, invalidf("file tree node %q is not a dictionary", name)
+		}
+		path := append(append([]string{}, dir...), name)
+
+		// a file is a node with a single empty key
+		if entry, isFile := node[""]; isFile {
+			if len(node) != 1 {
+				return nil, invalidf("file %q has children", name)
+			}
+			file, err := parseTreeFile(entry, path)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+			continue
+		}
+
+		children, err := parseFileTree(node, path, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, children...)
+	}
+
+	return
+}
+
+func parseTreeFile(entry any, path []string) (file File, err error) {
+	properties, ok := entry.(map[string]any)
+	if !ok {
+		return file, invalidf("file %q is not a dictionary", path)
+	}
+	fields := dict(properties)
+
+	file.Path = path
+	file.Length, err = fields.int("length")
+	if err != nil {
+		return
+	}
+	if _, ok := properties["length"]; !ok || file.Length < 0 {
+		return file, invalidf("missing length of file %q", path)
+	}
+
+	file.Attr, err = fields.string("attr")
+	if err != nil {
+		return
+	}
+
+	root, err := fields.string("pieces root")
+	if err != nil {
+		return
+	}
+	if (file.Length > 0) != (len(root) == sha256.Size) || (file.Length == 0 && root != "") {
+		return file, invalidf("invalid pieces root of file %q", path)
+	}
+	if root != "" {
+		file.PiecesRoot = []byte(root)
+	}
+
+	return
+}
+
+func sameFile(a, b File) bool {
+	if a.Length != b.Length || len(a.Path) != len(b.Path) {
+		return false
+	}
+	for i := range a.Path {
+		if a.Path[i] != b.Path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// validPathComponent rejects the names that could escape the torrent directory
+func validPathComponent(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, "/\\\x00")
+}
+
+// dict reads the optional keys of a dictionary, a missing key returns the zero value and a key of the wrong type an error
+type dict map[string]any
+
+func (d dict) string(key string) (string, error) {
+	value, ok := d[key]
+	if !ok {
+		return "", nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", invalidf("%s is not a string", key)
+	}
+	return s, nil
+}
+
+func (d dict) int(key string) (int64, error) {
+	value, ok := d[key]
+	if !ok {
+		return 0, nil
+	}
+	n, ok := value.(int64)
+	if !ok {
+		return 0, invalidf("%s is not an integer", key)
+	}
+	return n, nil
+}
+
+func stringList(value any) (list []string, err error) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, invalidf("not a list")
+	}
+
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, invalidf("not a list of strings")
+		}
+		list = append(list, s)
+	}
+
+	return
+}
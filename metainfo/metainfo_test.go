@@ -0,0 +1,201 @@
+package metainfo
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testTorrents are bencoded by hand, their hashes were computed independently with the SHA-1 and SHA-256 of the
+// info dictionary
+var testTorrents = []struct {
+	name           string
+	data           string
+	hashV1, hashV2 string
+	hash           string
+	single         bool
+	files          []File
+}{
+	{
+		name:   "v1 single file",
+		data:   "d8:announce35:http://tracker.example.com/announce7:comment6:single13:creation datei1700000000e4:infod6:lengthi40000e4:name10:ubuntu.iso12:piece lengthi16384e6:pieces60:" + strings.Repeat("a", 60) + "ee",
+		hashV1: "ee92c67c0562af429c160619106365a88398b532",
+		hash:   "ee92c67c0562af429c160619106365a88398b532",
+		single: true,
+		files:  []File{{Path: []string{"ubuntu.iso"}, Length: 40000}},
+	},
+	{
+		name:   "v1 multiple files",
+		data:   "d13:announce-listll24:udp://a.example.com:1337el24:udp://b.example.com:1337ee4:infod5:filesld6:lengthi20000e4:pathl5:a.bineed6:lengthi10e4:pathl3:sub5:b.txteee4:name3:dir12:piece lengthi16384e6:pieces40:" + strings.Repeat("b", 40) + "7:privatei1e6:source3:SRCe8:url-listl22:http://ws.example.com/ee",
+		hashV1: "93021f13ecd7a57dd5854b076136fca80958ae48",
+		hash:   "93021f13ecd7a57dd5854b076136fca80958ae48",
+		files:  []File{{Path: []string{"a.bin"}, Length: 20000}, {Path: []string{"sub", "b.txt"}, Length: 10}},
+	},
+	{
+		name:   "v2",
+		data:   "d4:infod9:file treed5:a.bind0:d6:lengthi10000e11:pieces root32:" + strings.Repeat("A", 32) + "ee3:subd5:b.txtd0:d6:lengthi10e11:pieces root32:" + strings.Repeat("B", 32) + "eeee12:meta versioni2e4:name3:dir12:piece lengthi16384eee",
+		hashV2: "d5bb126b19553475a580253dd15821583c4355f245bf74dac5fdf7177ddc3909",
+		hash:   "d5bb126b19553475a580253dd15821583c4355f2",
+		files: []File{
+			{Path: []string{"a.bin"}, Length: 10000, PiecesRoot: []byte(strings.Repeat("A", 32))},
+			{Path: []string{"sub", "b.txt"}, Length: 10, PiecesRoot: []byte(strings.Repeat("B", 32))},
+		},
+	},
+	{
+		name:   "hybrid",
+		data:   "d4:infod9:file treed5:a.bind0:d6:lengthi10000e11:pieces root32:" + strings.Repeat("A", 32) + "ee3:subd5:b.txtd0:d6:lengthi10e11:pieces root32:" + strings.Repeat("B", 32) + "eeee5:filesld6:lengthi10000e4:pathl5:a.bineed4:attr1:p6:lengthi6384e4:pathl4:.pad4:6384eed6:lengthi10e4:pathl3:sub5:b.txteee12:meta versioni2e4:name3:dir12:piece lengthi16384e6:pieces40:" + strings.Repeat("c", 40) + "ee",
+		hashV1: "93885212200cfcb4de969ca2519d2b4556327878",
+		hashV2: "4bd5f6311cddddb96ebe5b1c16280375c1e4e8f66670f58b2da72ac4cb91145b",
+		hash:   "93885212200cfcb4de969ca2519d2b4556327878",
+		files: []File{
+			{Path: []string{"a.bin"}, Length: 10000, PiecesRoot: []byte(strings.Repeat("A", 32))},
+			{Path: []string{".pad", "6384"}, Length: 6384, Attr: "p"},
+			{Path: []string{"sub", "b.txt"}, Length: 10, PiecesRoot: []byte(strings.Repeat("B", 32))},
+		},
+	},
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range testTorrents {
+		t.Run(test.name, func(t *testing.T) {
+			meta, err := Parse([]byte(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if meta.HashV1 != test.hashV1 || meta.HashV2 != test.hashV2 || meta.Hash() != test.hash {
+				t.Fatalf("got hashes v1=%q v2=%q hash=%q, want v1=%q v2=%q hash=%q",
+					meta.HashV1, meta.HashV2, meta.Hash(), test.hashV1, test.hashV2, test.hash)
+			}
+			if meta.IsV1() != (test.hashV1 != "") || meta.IsV2() != (test.hashV2 != "") || meta.IsHybrid() != (test.hashV1 != "" && test.hashV2 != "") {
+				t.Fatalf("got IsV1=%v IsV2=%v IsHybrid=%v", meta.IsV1(), meta.IsV2(), meta.IsHybrid())
+			}
+
+			if meta.Info.SingleFile != test.single || !reflect.DeepEqual(meta.Info.Files, test.files) {
+				t.Fatalf("got single=%v files=%+v, want single=%v files=%+v", meta.Info.SingleFile, meta.Info.Files, test.single, test.files)
+			}
+
+			// the info bytes are the ones of the file, which are hashed
+			start := strings.Index(test.data, "4:infod") + len("4:info")
+			if !bytes.HasPrefix([]byte(test.data[start:]), meta.InfoBytes) || len(meta.InfoBytes) == 0 {
+				t.Fatalf("got info bytes %q", meta.InfoBytes)
+			}
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	single, err := Parse([]byte(testTorrents[0].data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if single.Info.Name != "ubuntu.iso" || single.Info.PieceLength != 16384 || single.Info.NumPieces() != 3 ||
+		single.Info.TotalLength() != 40000 || single.Info.MetaVersion != 1 || single.Info.Private {
+		t.Fatalf("got info %+v", single.Info)
+	}
+	if single.Comment != "single" || !single.CreationDate.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("got comment %q and creation date %v", single.Comment, single.CreationDate)
+	}
+	if trackers := single.Trackers(); !reflect.DeepEqual(trackers, []string{"http://tracker.example.com/announce"}) {
+		t.Fatalf("got trackers %v", trackers)
+	}
+
+	multi, err := Parse([]byte(testTorrents[1].data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !multi.Info.Private || multi.Info.Source != "SRC" || !reflect.DeepEqual(multi.URLList, []string{"http://ws.example.com/"}) {
+		t.Fatalf("got private=%v source=%q url-list=%v", multi.Info.Private, multi.Info.Source, multi.URLList)
+	}
+	if trackers := multi.Trackers(); !reflect.DeepEqual(trackers, []string{"udp://a.example.com:1337", "udp://b.example.com:1337"}) {
+		t.Fatalf("got trackers %v", trackers)
+	}
+
+	hybrid, err := Parse([]byte(testTorrents[3].data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the padding file is not part of the size of the torrent
+	if hybrid.Info.TotalLength() != 10010 || hybrid.Info.MetaVersion != 2 {
+		t.Fatalf("got total length %d and meta version %d", hybrid.Info.TotalLength(), hybrid.Info.MetaVersion)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	info := func(fields string) string {
+		return "d4:infod" + fields + "ee"
+	}
+	pieces := "6:pieces20:" + strings.Repeat("p", 20)
+
+	invalid := map[string]string{
+		"not a dictionary":     "le",
+		"missing info":         "d8:announce1:xe",
+		"missing name":         info("6:lengthi1e12:piece lengthi16384e" + pieces),
+		"missing pieces":       info("6:lengthi1e4:name1:a12:piece lengthi16384e"),
+		"zero piece length":    info("6:lengthi1e4:name1:a12:piece lengthi0e" + pieces),
+		"pieces count":         info("6:lengthi20000e4:name1:a12:piece lengthi16384e" + pieces),
+		"name escaping":        info("6:lengthi1e4:name2:..12:piece lengthi16384e" + pieces),
+		"path escaping":        info("5:filesld6:lengthi1e4:pathl2:..3:etceee4:name1:a12:piece lengthi16384e" + pieces),
+		"path with separator":  info("5:filesld6:lengthi1e4:pathl5:a/../eee4:name1:a12:piece lengthi16384e" + pieces),
+		"empty files":          info("5:filesle4:name1:a12:piece lengthi16384e" + pieces),
+		"negative length":      info("6:lengthi-1e4:name1:a12:piece lengthi16384e" + pieces),
+		"v2 piece length":      info("9:file treed1:ad0:d6:lengthi1e11:pieces root32:" + strings.Repeat("r", 32) + "eee12:meta versioni2e4:name1:a12:piece lengthi1000e"),
+		"v2 missing file tree": info("12:meta versioni2e4:name1:a12:piece lengthi16384e"),
+		"unknown meta version": info("6:lengthi1e12:meta versioni3e4:name1:a12:piece lengthi16384e" + pieces),
+		"hybrid file mismatch": info("9:file treed1:bd0:d6:lengthi1e11:pieces root32:" + strings.Repeat("r", 32) + "eee5:filesld6:lengthi1e4:pathl1:aeee12:meta versioni2e4:name1:d12:piece lengthi16384e" + pieces),
+	}
+
+	for name, data := range invalid {
+		_, err := Parse([]byte(data))
+		if !errors.Is(err, ErrInvalidMetaInfo) {
+			t.Errorf("%s: got error %v, want ErrInvalidMetaInfo", name, err)
+		}
+	}
+
+	var syntaxErr *SyntaxError
+	if _, err := Parse([]byte("d4:info")); !errors.As(err, &syntaxErr) {
+		t.Errorf("invalid bencode: got error %v, want a *SyntaxError", err)
+	}
+}
+
+func TestHashWithoutInfoHash(t *testing.T) {
+	for _, meta := range []*MetaInfo{{}, {HashV2: "abc"}} {
+		if hash := meta.Hash(); hash != "" {
+			t.Errorf("got hash %q for %+v, want an empty hash", hash, meta)
+		}
+	}
+}
+
+// FuzzParse checks that Parse never panics, and that a parsed torrent can be identified.
+func FuzzParse(f *testing.F) {
+	for _, torrent := range testTorrents {
+		f.Add([]byte(torrent.data))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		meta, err := Parse(data)
+		if err != nil {
+			return
+		}
+
+		if len(meta.Hash()) != 40 {
+			t.Fatalf("got hash %q", meta.Hash())
+		}
+		if !bytes.Contains(data, meta.InfoBytes) {
+			t.Fatal("the info bytes are not the ones of the data")
+		}
+		for _, file := range meta.Info.Files {
+			for _, component := range file.Path {
+				if component == "" || component == "." || component == ".." || strings.ContainsAny(component, `/\`) {
+					t.Fatalf("got invalid path %q", file.Path)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package qbittorrent
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+const defaultUserAgent = "qbittorrent-webapi-go v1.0"
+
+// ClientOption configures a [Client], pass them to [NewClient].
+type ClientOption func(cfg *clientConfig)
+
+type clientConfig struct {
+	httpClient         *http.Client
+	transport          http.RoundTripper
+	timeout            time.Duration
+	rootCAs            *x509.CertPool
+	pins               [][]byte
+	insecureSkipVerify bool
+	userAgent          string
+	headers            http.Header
+	jar                http.CookieJar
+	retry              *RetryPolicy
+	sessions           SessionStore
+	sessionErrors      func(err error)
+	credentials        CredentialProvider
+	authBypass         bool
+}
+
+// WithHTTPClient uses a copy of httpClient to send the requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = httpClient
+	}
+}
+
+// WithTransport sends the requests using the given RoundTripper.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.transport = transport
+	}
+}
+
+// WithTimeout limits the time of each request, including reading the response body.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithRootCAs verifies the server certificate using the given certificate authorities instead of the system ones.
+// Like the other TLS options, it needs the transport of the client to be an *http.Transport, see [NewClient].
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.rootCAs = pool
+	}
+}
+
+/*
+WithPinnedCertificate only accepts a server presenting a certificate with the given SHA-256 fingerprint.
+The certificate chain is not verified, which allows self-signed certificates.
+
+The fingerprint can be obtained with:
+
+	openssl x509 -in cert.pem -noout -fingerprint -sha256
+*/
+func WithPinnedCertificate(sha256Fingerprint []byte) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.pins = append(cfg.pins, sha256Fingerprint)
+	}
+}
+
+// WithInsecureSkipVerify accepts any certificate presented by the server. Only use it for testing.
+func WithInsecureSkipVerify() ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.insecureSkipVerify = true
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.userAgent = userAgent
+	}
+}
+
+// WithHeader adds a header to every request.
+func WithHeader(key, value string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.headers.Add(key, value)
+	}
+}
+
+// WithCookieJar stores the session cookie in the given jar.
+func WithCookieJar(jar http.CookieJar) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.jar = jar
+	}
+}
+
+// WithRetryPolicy retries the requests that fail with a transient error using policy, pass nil to disable retries.
+// The default policy is [DefaultRetryPolicy].
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.retry = policy
+	}
+}
+
+/*
+WithSessionStore keeps the session in store so it can be reused by the next client, see [SessionStore].
+
+[Client.Login] first tries to restore the stored session and only logs in when it's missing, expired or
+rejected by the server. Every new session is saved to store, and [Client.Logout] deletes it.
+
+A stored session that can't be loaded, e.g. a corrupt file, is deleted and the client logs in. A session that
+can't be saved doesn't fail the login. Use [WithSessionErrorHandler] to be told about these errors.
+*/
+func WithSessionStore(store SessionStore) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.sessions = store
+	}
+}
+
+// WithSessionErrorHandler calls handler with the errors of the session store that don't fail the login:
+// a stored session that can't be loaded or deleted, and a new session that can't be saved.
+func WithSessionErrorHandler(handler func(err error)) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.sessionErrors = handler
+	}
+}
+
+// WithCredentials logs in with provider when the first request is rejected, without calling [Client.Login].
+func WithCredentials(provider CredentialProvider) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.credentials = provider
+	}
+}
+
+// WithBasicAuth sends HTTP basic auth credentials with every request, for a reverse proxy in front of the WebUI.
+// It's independent of the qBittorrent login, which can be used as well.
+func WithBasicAuth(username, password string) ClientOption {
+	return func(cfg *clientConfig) {
+		auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		cfg.headers.Set("Authorization", "Basic "+auth)
+	}
+}
+
+// WithBearerToken sends the token in the Authorization header of every request, for a reverse proxy in front of the WebUI.
+// It's independent of the qBittorrent login, which can be used as well.
+func WithBearerToken(token string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.headers.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// WithAuthBypass is used for servers that don't require logging in, e.g. when the WebUI bypasses the authentication
+// for localhost or whitelisted subnets, or when a reverse proxy handles it.
+// [Client.Login] does nothing and a 403 response is returned without logging in again.
+func WithAuthBypass() ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.authBypass = true
+	}
+}
+
+// apply builds the http client of c from the config, the error is returned by every request of c
+func (cfg *clientConfig) apply(c *Client) (err error) {
+	httpClient := &http.Client{}
+	if cfg.httpClient != nil {
+		*httpClient = *cfg.httpClient
+	}
+
+	if cfg.transport != nil {
+		httpClient.Transport = cfg.transport
+	}
+
+	if cfg.rootCAs != nil || len(cfg.pins) > 0 || cfg.insecureSkipVerify {
+		// TLS settings can only be applied to an *http.Transport
+		roundTripper := httpClient.Transport
+		if roundTripper == nil {
+			roundTripper = http.DefaultTransport
+		}
+		base, ok := roundTripper.(*http.Transport)
+		if ok {
+			transport := base.Clone()
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{}
+			}
+			cfg.applyTLS(transport.TLSClientConfig)
+			httpClient.Transport = transport
+		} else {
+			// ignoring them would connect without the certificate checks the caller asked for
+			err = fmt.Errorf("%w: WithRootCAs, WithPinnedCertificate and WithInsecureSkipVerify need an *http.Transport, got %T", ErrInvalidOptions, roundTripper)
+		}
+	}
+
+	if cfg.timeout > 0 {
+		httpClient.Timeout = cfg.timeout
+	}
+
+	switch {
+	case cfg.jar != nil:
+		httpClient.Jar = cfg.jar
+	case httpClient.Jar == nil:
+		httpClient.Jar, _ = cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	}
+
+	c.http = httpClient
+	c.Jar = httpClient.Jar
+	c.userAgent = cfg.userAgent
+	c.headers = cfg.headers
+	c.retry = cfg.retry
+	c.sessions = cfg.sessions
+	c.sessionErrors = cfg.sessionErrors
+	c.credentials = cfg.credentials
+	c.authBypass = cfg.authBypass
+
+	return
+}
+
+func (cfg *clientConfig) applyTLS(tlsConfig *tls.Config) {
+	if cfg.rootCAs != nil {
+		tlsConfig.RootCAs = cfg.rootCAs
+	}
+
+	if cfg.insecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if len(cfg.pins) > 0 {
+		pins := cfg.pins
+		// the chain is replaced by the fingerprint check
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("qbittorrent: server did not present a certificate")
+			}
+			sum := sha256.Sum256(rawCerts[0])
+			for _, pin := range pins {
+				if bytes.Equal(pin, sum[:]) {
+					return nil
+				}
+			}
+			return errors.New("qbittorrent: server certificate does not match the pinned fingerprint")
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package qbittorrent_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTLSOptionsNeedHTTPTransport(t *testing.T) {
+	custom := roundTripperFunc(http.DefaultTransport.RoundTrip)
+
+	for name, opts := range map[string][]qbittorrent.ClientOption{
+		"WithTransport":  {qbittorrent.WithTransport(custom), qbittorrent.WithPinnedCertificate(make([]byte, sha256.Size))},
+		"WithHTTPClient": {qbittorrent.WithHTTPClient(&http.Client{Transport: custom}), qbittorrent.WithInsecureSkipVerify()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+			}))
+			defer srv.Close()
+
+			client := qbittorrent.NewClient(srv.URL, opts...)
+
+			// nothing is sent without the certificate checks
+			if err := client.Login("admin", "adminadmin"); !errors.Is(err, qbittorrent.ErrInvalidOptions) {
+				t.Fatalf("Login: got error %v, want ErrInvalidOptions", err)
+			}
+			if _, err := client.GetAPIVersion(); !errors.Is(err, qbittorrent.ErrInvalidOptions) {
+				t.Fatalf("GetAPIVersion: got error %v, want ErrInvalidOptions", err)
+			}
+			if _, err := client.GetTorrentList(nil); !errors.Is(err, qbittorrent.ErrInvalidOptions) {
+				t.Fatalf("GetTorrentList: got error %v, want ErrInvalidOptions", err)
+			}
+			if n := requests.Load(); n != 0 {
+				t.Fatalf("got %d requests, want 0", n)
+			}
+		})
+	}
+}
+
+func TestPinnedCertificate(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("2.9.3"))
+	}))
+	defer srv.Close()
+
+	fingerprint := sha256.Sum256(srv.Certificate().Raw)
+
+	// the transport given by the caller is used with the pinned certificate
+	client := qbittorrent.NewClient(srv.URL, qbittorrent.WithAuthBypass(),
+		qbittorrent.WithTransport(&http.Transport{}),
+		qbittorrent.WithPinnedCertificate(fingerprint[:]),
+	)
+	if _, err := client.GetAPIVersion(); err != nil {
+		t.Fatal(err)
+	}
+
+	client = qbittorrent.NewClient(srv.URL, qbittorrent.WithAuthBypass(), qbittorrent.WithPinnedCertificate(make([]byte, sha256.Size)))
+	if _, err := client.GetAPIVersion(); err == nil {
+		t.Fatal("certificate with another fingerprint accepted")
+	}
+}
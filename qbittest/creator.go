@@ -0,0 +1,178 @@
+package qbittest
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+type creatorTask struct {
+	info    qbittorrent.TorrentCreatorTask
+	started time.Time
+	err     string // error message of a task set to fail
+}
+
+// SetTorrentCreatorDuration sets how long torrent creation tasks run. The default of 0 makes them finish immediately.
+func (s *Server) SetTorrentCreatorDuration(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.creatorDuration = d
+}
+
+// SetTorrentCreatorError makes the next torrent creation tasks fail with message, an empty message makes them succeed again.
+func (s *Server) SetTorrentCreatorError(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.creatorError = message
+}
+
+// creatorStatus updates the status of a task from the time it has been running, the caller must hold s.mu
+func (s *Server) creatorStatus(task *creatorTask) qbittorrent.TorrentCreatorTask {
+	info := task.info
+	elapsed := time.Since(task.started)
+	if s.creatorDuration > 0 && elapsed < s.creatorDuration {
+		info.Status = qbittorrent.TorrentCreatorStatusRunning
+		info.Progress = 100 * float64(elapsed) / float64(s.creatorDuration)
+		return info
+	}
+
+	info.TimeFinished = task.started.Add(s.creatorDuration).Format(time.RFC1123)
+	if task.err != "" {
+		info.Status = qbittorrent.TorrentCreatorStatusFailed
+		info.ErrorMessage = task.err
+		return info
+	}
+
+	info.Status = qbittorrent.TorrentCreatorStatusFinished
+	info.Progress = 100
+	return info
+}
+
+// creatorTorrentFile returns a minimal bencoded torrent for the task
+func creatorTorrentFile(info qbittorrent.TorrentCreatorTask) []byte {
+	bstr := func(v string) string { return fmt.Sprintf("%d:%s", len(v), v) }
+
+	var b strings.Builder
+	b.WriteString("d")
+	if len(info.Trackers) > 0 {
+		b.WriteString(bstr("announce") + bstr(info.Trackers[0]))
+	}
+	if info.Comment != "" {
+		b.WriteString(bstr("comment") + bstr(info.Comment))
+	}
+	b.WriteString(bstr("info") + "d")
+	b.WriteString(bstr("length") + "i0e")
+	b.WriteString(bstr("name") + bstr(path.Base(info.SourcePath)))
+	b.WriteString(bstr("piece length") + fmt.Sprintf("i%de", max(info.PieceSize, 16384)))
+	b.WriteString(bstr("pieces") + bstr(""))
+	if info.Private {
+		b.WriteString(bstr("private") + "i1e")
+	}
+	if info.Source != "" {
+		b.WriteString(bstr("source") + bstr(info.Source))
+	}
+	b.WriteString("ee")
+
+	return []byte(b.String())
+}
+
+func (s *Server) registerTorrentCreator(mux *http.ServeMux) {
+	since := func(handler http.HandlerFunc) http.HandlerFunc {
+		return s.since("2.11.2", handler)
+	}
+
+	// task lookups answering 404 for unknown IDs
+	lookup := func(handler func(w http.ResponseWriter, r *http.Request, task *creatorTask)) http.HandlerFunc {
+		return since(func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			task, ok := s.creatorTasks[r.FormValue("taskID")]
+			if !ok {
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+			handler(w, r, task)
+		})
+	}
+
+	mux.HandleFunc("POST /api/v2/torrentcreator/addTask", since(func(w http.ResponseWriter, r *http.Request) {
+		sourcePath := r.FormValue("sourcePath")
+		if sourcePath == "" {
+			http.Error(w, "Missing sourcePath", http.StatusBadRequest)
+			return
+		}
+
+		format := qbittorrent.TorrentFormat(r.FormValue("format"))
+		if format == "" {
+			format = qbittorrent.TorrentFormatHybrid
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		now := time.Now()
+		task := &creatorTask{
+			started: now,
+			err:     s.creatorError,
+			info: qbittorrent.TorrentCreatorTask{
+				TaskId:          randomHex(16),
+				SourcePath:      sourcePath,
+				TorrentFilePath: r.FormValue("torrentFilePath"),
+				Format:          format,
+				Private:         r.FormValue("private") == "true",
+				Comment:         r.FormValue("comment"),
+				Source:          r.FormValue("source"),
+				Trackers:        splitList(r.FormValue("trackers"), "|"),
+				UrlSeeds:        splitList(r.FormValue("urlSeeds"), "|"),
+				Status:          qbittorrent.TorrentCreatorStatusQueued,
+				TimeAdded:       now.Format(time.RFC1123),
+				TimeStarted:     now.Format(time.RFC1123),
+			},
+		}
+		fmt.Sscan(r.FormValue("pieceSize"), &task.info.PieceSize)
+
+		s.creatorTasks[task.info.TaskId] = task
+		writeJSON(w, qbittorrent.TorrentCreatorTaskId{TaskId: task.info.TaskId})
+	}))
+
+	mux.HandleFunc("/api/v2/torrentcreator/status", since(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		results := []qbittorrent.TorrentCreatorTask{}
+		if id := r.FormValue("taskID"); id != "" {
+			task, ok := s.creatorTasks[id]
+			if !ok {
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+			results = append(results, s.creatorStatus(task))
+		} else {
+			for _, task := range s.creatorTasks {
+				results = append(results, s.creatorStatus(task))
+			}
+		}
+		writeJSON(w, results)
+	}))
+
+	mux.HandleFunc("/api/v2/torrentcreator/torrentFile", lookup(func(w http.ResponseWriter, r *http.Request, task *creatorTask) {
+		info := s.creatorStatus(task)
+		if info.Status != qbittorrent.TorrentCreatorStatusFinished {
+			http.Error(w, "Torrent creation is still in progress or failed", http.StatusConflict)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-bittorrent")
+		w.Write(creatorTorrentFile(info))
+	}))
+
+	mux.HandleFunc("POST /api/v2/torrentcreator/deleteTask", lookup(func(w http.ResponseWriter, r *http.Request, task *creatorTask) {
+		delete(s.creatorTasks, task.info.TaskId)
+	}))
+}
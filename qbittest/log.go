@@ -0,0 +1,94 @@
+package qbittest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+// AddLog appends a message to the main log and returns its id.
+func (s *Server) AddLog(msgType qbittorrent.LogMessageType, message string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := len(s.logs)
+	s.logs = append(s.logs, qbittorrent.GetLogResponse{
+		Id:        id,
+		Message:   message,
+		Timestamp: int(time.Now().Unix()),
+		Type:      msgType,
+	})
+	return id
+}
+
+// AddPeerLog appends an entry to the peer log and returns its id.
+func (s *Server) AddPeerLog(ip string, blocked bool, reason string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := len(s.peerLogs)
+	s.peerLogs = append(s.peerLogs, qbittorrent.GetPeerLogResponse{
+		Id:        id,
+		Ip:        ip,
+		Timestamp: int(time.Now().Unix()),
+		Blocked:   blocked,
+		Reason:    reason,
+	})
+	return id
+}
+
+// ClearLogs empties both logs and resets their ids, like a restart of qBittorrent does.
+func (s *Server) ClearLogs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logs = nil
+	s.peerLogs = nil
+}
+
+func (s *Server) registerLog(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v2/log/main", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		include := map[qbittorrent.LogMessageType]bool{
+			qbittorrent.LogNormal:   query.Get("normal") != "false",
+			qbittorrent.LogInfo:     query.Get("info") != "false",
+			qbittorrent.LogWarn:     query.Get("warning") != "false",
+			qbittorrent.LogCritical: query.Get("critical") != "false",
+		}
+		lastKnownId := -1
+		if v := query.Get("last_known_id"); v != "" {
+			lastKnownId, _ = strconv.Atoi(v)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		results := []qbittorrent.GetLogResponse{}
+		for _, msg := range s.logs {
+			if msg.Id > lastKnownId && include[msg.Type] {
+				results = append(results, msg)
+			}
+		}
+		writeJSON(w, results)
+	})
+
+	mux.HandleFunc("GET /api/v2/log/peers", func(w http.ResponseWriter, r *http.Request) {
+		lastKnownId := -1
+		if v := r.URL.Query().Get("last_known_id"); v != "" {
+			lastKnownId, _ = strconv.Atoi(v)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		results := []qbittorrent.GetPeerLogResponse{}
+		for _, entry := range s.peerLogs {
+			if entry.Id > lastKnownId {
+				results = append(results, entry)
+			}
+		}
+		writeJSON(w, results)
+	})
+}
@@ -0,0 +1,186 @@
+package qbittest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// rssItem is a folder or a feed of the RSS tree, the root folder has an empty path
+type rssItem struct {
+	url      string   // feed URL, empty for folders
+	uid      string   // feed UID
+	children []string // paths of the items of a folder, nil for feeds
+}
+
+// separator of the RSS item paths
+const rssSep = `\`
+
+func rssParent(itemPath string) string {
+	if i := strings.LastIndex(itemPath, rssSep); i >= 0 {
+		return itemPath[:i]
+	}
+	return ""
+}
+
+func rssName(itemPath string) string {
+	return itemPath[strings.LastIndex(itemPath, rssSep)+1:]
+}
+
+// addRSSItem adds an item to its parent folder, the caller must hold s.mu
+func (s *Server) addRSSItem(itemPath string, item *rssItem) bool {
+	parent, ok := s.rssItems[rssParent(itemPath)]
+	if _, exists := s.rssItems[itemPath]; exists || itemPath == "" || !ok || parent.children == nil {
+		return false
+	}
+
+	parent.children = append(parent.children, itemPath)
+	s.rssItems[itemPath] = item
+	return true
+}
+
+// removeRSSItem removes an item and its children, the caller must hold s.mu
+func (s *Server) removeRSSItem(itemPath string) bool {
+	item, ok := s.rssItems[itemPath]
+	if !ok || itemPath == "" {
+		return false
+	}
+
+	for _, child := range append([]string(nil), item.children...) {
+		s.removeRSSItem(child)
+	}
+
+	parent := s.rssItems[rssParent(itemPath)]
+	parent.children = remove(parent.children, itemPath)
+	delete(s.rssItems, itemPath)
+	return true
+}
+
+// rssTree returns the JSON representation of a folder, the caller must hold s.mu
+func (s *Server) rssTree(folder *rssItem) map[string]interface{} {
+	results := make(map[string]interface{})
+	for _, child := range folder.children {
+		item := s.rssItems[child]
+		if item.children != nil {
+			results[rssName(child)] = s.rssTree(item)
+		} else {
+			results[rssName(child)] = map[string]interface{}{"uid": item.uid, "url": item.url}
+		}
+	}
+	return results
+}
+
+func (s *Server) registerRSS(mux *http.ServeMux) {
+	conflict := func(w http.ResponseWriter) {
+		http.Error(w, "Conflict", http.StatusConflict)
+	}
+
+	mux.HandleFunc("POST /api/v2/rss/addFolder", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.addRSSItem(r.FormValue("path"), &rssItem{children: []string{}}) {
+			conflict(w)
+		}
+	})
+
+	mux.HandleFunc("POST /api/v2/rss/addFeed", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		feedUrl := r.FormValue("url")
+		itemPath := r.FormValue("path")
+		if itemPath == "" {
+			itemPath = feedUrl
+		}
+		for _, item := range s.rssItems {
+			if item.url == feedUrl {
+				conflict(w)
+				return
+			}
+		}
+		if !s.addRSSItem(itemPath, &rssItem{url: feedUrl, uid: "{" + randomHex(16) + "}"}) {
+			conflict(w)
+		}
+	})
+
+	mux.HandleFunc("POST /api/v2/rss/removeItem", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.removeRSSItem(r.FormValue("path")) {
+			conflict(w)
+		}
+	})
+
+	mux.HandleFunc("POST /api/v2/rss/moveItem", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		itemPath, destPath := r.FormValue("itemPath"), r.FormValue("destPath")
+		item, ok := s.rssItems[itemPath]
+		if !ok || item.children != nil || !s.addRSSItem(destPath, item) {
+			conflict(w)
+			return
+		}
+
+		parent := s.rssItems[rssParent(itemPath)]
+		parent.children = remove(parent.children, itemPath)
+		delete(s.rssItems, itemPath)
+	})
+
+	mux.HandleFunc("GET /api/v2/rss/items", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		writeJSON(w, s.rssTree(s.rssItems[""]))
+	})
+
+	mux.HandleFunc("POST /api/v2/rss/markAsRead", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("POST /api/v2/rss/refreshItem", func(w http.ResponseWriter, r *http.Request) {})
+
+	mux.HandleFunc("POST /api/v2/rss/setRule", func(w http.ResponseWriter, r *http.Request) {
+		var rule map[string]interface{}
+		err := json.Unmarshal([]byte(r.FormValue("ruleDef")), &rule)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.rssRules[r.FormValue("ruleName")] = rule
+	})
+
+	mux.HandleFunc("POST /api/v2/rss/renameRule", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		rule, ok := s.rssRules[r.FormValue("ruleName")]
+		if !ok {
+			conflict(w)
+			return
+		}
+		delete(s.rssRules, r.FormValue("ruleName"))
+		s.rssRules[r.FormValue("newRuleName")] = rule
+	})
+
+	mux.HandleFunc("POST /api/v2/rss/removeRule", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.rssRules, r.FormValue("ruleName"))
+	})
+
+	mux.HandleFunc("GET /api/v2/rss/rules", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		writeJSON(w, s.rssRules)
+	})
+
+	mux.HandleFunc("GET /api/v2/rss/matchingArticles", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string][]string{})
+	})
+}
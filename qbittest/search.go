@@ -0,0 +1,183 @@
+package qbittest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+// qBittorrent refuses to run more than 5 searches at the same time
+const maxRunningSearches = 5
+
+type searchJob struct {
+	started time.Time
+	stopped time.Time // zero while the job is not stopped explicitly
+	results []qbittorrent.SearchResult
+}
+
+// SetSearchResults sets the results found by the searches, a search only returns the results whose
+// file name contains all the words of its pattern.
+func (s *Server) SetSearchResults(results []qbittorrent.SearchResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.searchResults = results
+}
+
+// SetSearchDuration sets how long searches run, results are revealed progressively during that time.
+// The default of 0 makes searches end immediately.
+func (s *Server) SetSearchDuration(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.searchDuration = d
+}
+
+// elapsed returns how long the job has been running, the caller must hold s.mu
+func (s *Server) elapsed(job *searchJob) time.Duration {
+	end := time.Now()
+	if !job.stopped.IsZero() {
+		end = job.stopped
+	}
+	return end.Sub(job.started)
+}
+
+// status returns the state of a job and its visible results, the caller must hold s.mu
+func (s *Server) status(id int, job *searchJob) (qbittorrent.SearchStatusResponse, []qbittorrent.SearchResult) {
+	elapsed := s.elapsed(job)
+	if s.searchDuration <= 0 || elapsed >= s.searchDuration {
+		return qbittorrent.SearchStatusResponse{Id: id, Status: qbittorrent.SearchStatusStopped, Total: len(job.results)}, job.results
+	}
+
+	visible := job.results[:len(job.results)*int(elapsed)/int(s.searchDuration)]
+	status := qbittorrent.SearchStatusRunning
+	if !job.stopped.IsZero() {
+		status = qbittorrent.SearchStatusStopped
+	}
+	return qbittorrent.SearchStatusResponse{Id: id, Status: status, Total: len(visible)}, visible
+}
+
+func (s *Server) registerSearch(mux *http.ServeMux) {
+	// job lookups answering 404 for unknown ids
+	lookup := func(handler func(w http.ResponseWriter, r *http.Request, id int, job *searchJob)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			id, _ := strconv.Atoi(r.FormValue("id"))
+			job, ok := s.searchJobs[id]
+			if !ok {
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+			handler(w, r, id, job)
+		}
+	}
+
+	mux.HandleFunc("POST /api/v2/search/start", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		running := 0
+		for id, job := range s.searchJobs {
+			if status, _ := s.status(id, job); status.Status == qbittorrent.SearchStatusRunning {
+				running++
+			}
+		}
+		if running >= maxRunningSearches {
+			http.Error(w, "Unable to create more than 5 concurrent searches.", http.StatusConflict)
+			return
+		}
+
+		words := strings.Fields(strings.ToLower(r.FormValue("pattern")))
+		job := &searchJob{started: time.Now(), results: []qbittorrent.SearchResult{}}
+		for _, result := range s.searchResults {
+			name := strings.ToLower(result.FileName)
+			match := true
+			for _, word := range words {
+				match = match && strings.Contains(name, word)
+			}
+			if match {
+				job.results = append(job.results, result)
+			}
+		}
+
+		id := s.searchNextId
+		s.searchNextId++
+		s.searchJobs[id] = job
+
+		writeJSON(w, qbittorrent.SearchId{Id: id})
+	})
+
+	mux.HandleFunc("POST /api/v2/search/stop", lookup(func(w http.ResponseWriter, r *http.Request, id int, job *searchJob) {
+		if job.stopped.IsZero() {
+			job.stopped = time.Now()
+		}
+	}))
+
+	mux.HandleFunc("POST /api/v2/search/delete", lookup(func(w http.ResponseWriter, r *http.Request, id int, job *searchJob) {
+		delete(s.searchJobs, id)
+	}))
+
+	mux.HandleFunc("GET /api/v2/search/status", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		results := []qbittorrent.SearchStatusResponse{}
+		if v := r.FormValue("id"); v != "" {
+			id, _ := strconv.Atoi(v)
+			job, ok := s.searchJobs[id]
+			if !ok {
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+			status, _ := s.status(id, job)
+			results = append(results, status)
+		} else {
+			for id, job := range s.searchJobs {
+				status, _ := s.status(id, job)
+				results = append(results, status)
+			}
+		}
+		writeJSON(w, results)
+	})
+
+	mux.HandleFunc("GET /api/v2/search/results", lookup(func(w http.ResponseWriter, r *http.Request, id int, job *searchJob) {
+		status, visible := s.status(id, job)
+
+		offset, _ := strconv.Atoi(r.FormValue("offset"))
+		if offset < 0 {
+			offset += len(visible)
+		}
+		if offset < 0 || offset > len(visible) {
+			http.Error(w, "Conflict", http.StatusConflict)
+			return
+		}
+		visible = visible[offset:]
+		if limit, _ := strconv.Atoi(r.FormValue("limit")); limit > 0 && limit < len(visible) {
+			visible = visible[:limit]
+		}
+
+		writeJSON(w, qbittorrent.SearchResultsResponse{Results: visible, Status: status.Status, Total: status.Total})
+	}))
+
+	mux.HandleFunc("GET /api/v2/search/plugins", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []qbittorrent.SearchPluginsResponse{{
+			Enabled:             true,
+			FullName:            "Fake search engine",
+			Name:                "fake",
+			SupportedCategories: []qbittorrent.SearchCategory{{Id: "all", Name: "All categories"}},
+			Url:                 "http://localhost",
+			Version:             "1.0",
+		}})
+	})
+
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+	mux.HandleFunc("POST /api/v2/search/installPlugin", noop)
+	mux.HandleFunc("POST /api/v2/search/uninstallPlugin", noop)
+	mux.HandleFunc("POST /api/v2/search/enablePlugin", noop)
+	mux.HandleFunc("POST /api/v2/search/updatePlugins", noop)
+}
@@ -0,0 +1,342 @@
+/*
+Package qbittest provides an in-process fake qBittorrent WebUI server for testing code that uses
+[qbittorrent.Client] without a real qBittorrent instance.
+
+The server implements the `/api/v2` endpoints wrapped by the qbittorrent package on top of in-memory
+state, and has hooks to inject failures, latency and session expiry.
+
+# Example
+
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: "8c212779b4abde7c6bc608063a0d008b7e40ce32", Name: "ubuntu"})
+
+	client := qbittorrent.NewClient(srv.URL)
+	err := client.Login(qbittest.DefaultUsername, qbittest.DefaultPassword)
+	if err != nil {
+	 panic(err)
+	}
+
+	torrents, err := client.GetTorrentList(nil)
+
+Torrents added from .torrent files are identified by their info hash and get their files, so the uploaded files
+have to be valid torrents, see the metainfo package.
+*/
+package qbittest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+const (
+	DefaultUsername   = "admin"
+	DefaultPassword   = "adminadmin"
+	DefaultVersion    = "v4.6.7" // Default application version reported by the server
+	DefaultAPIVersion = "2.9.3"  // Default WebAPI version reported by the server
+)
+
+const sessionCookie = "SID"
+
+// Hook is called before every request. Returning true means the hook wrote the response and the
+// request is not handled any further.
+type Hook func(w http.ResponseWriter, r *http.Request) (handled bool)
+
+// Server is a fake qBittorrent WebUI server. Use [NewServer] to create one.
+//
+// The exported fields can be changed before the first request is made. The other settings and the
+// in-memory state are changed with the methods of the server, which are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	Username       string // Username accepted by the login endpoint
+	Password       string // Password accepted by the login endpoint
+	Version        string // Application version (e.g. v4.6.7)
+	APIVersion     string // WebAPI version (e.g. 2.9.3)
+	BypassAuth     bool   // Accept requests without a session, like the "bypass authentication for clients on localhost" setting
+	MaxLoginFailed int    // Ban the client after this many failed login attempts. 0 means never
+
+	mu          sync.Mutex
+	hooks       []Hook
+	latency     time.Duration
+	requests    map[string]int
+	sessions    map[string]bool
+	loginFailed int
+
+	torrents    map[string]*torrent
+	categories  map[string]qbittorrent.Category
+	tags        map[string]bool
+	serverState qbittorrent.ServerState
+
+	syncRid       int
+	syncSnapshots map[int]*syncSnapshot
+	peersRid      int
+	peerSnapshots map[int]*peerSnapshot
+
+	logs     []qbittorrent.GetLogResponse
+	peerLogs []qbittorrent.GetPeerLogResponse
+
+	rssItems map[string]*rssItem
+	rssRules map[string]map[string]interface{}
+
+	searchJobs     map[int]*searchJob
+	searchNextId   int
+	searchResults  []qbittorrent.SearchResult
+	searchDuration time.Duration
+
+	creatorTasks    map[string]*creatorTask
+	creatorDuration time.Duration
+	creatorError    string
+}
+
+// NewServer starts and returns a new fake server, the caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		Username:   DefaultUsername,
+		Password:   DefaultPassword,
+		Version:    DefaultVersion,
+		APIVersion: DefaultAPIVersion,
+
+		requests:      make(map[string]int),
+		sessions:      make(map[string]bool),
+		torrents:      make(map[string]*torrent),
+		categories:    make(map[string]qbittorrent.Category),
+		tags:          make(map[string]bool),
+		syncSnapshots: make(map[int]*syncSnapshot),
+		peerSnapshots: make(map[int]*peerSnapshot),
+		rssItems:      map[string]*rssItem{"": {children: []string{}}},
+		rssRules:      make(map[string]map[string]interface{}),
+		searchJobs:    make(map[int]*searchJob),
+		creatorTasks:  make(map[string]*creatorTask),
+
+		serverState: qbittorrent.ServerState{
+			ConnectionStatus: qbittorrent.Connected,
+			RefreshInterval:  1500,
+			GlobalRatio:      "0.00",
+		},
+	}
+
+	s.Server = httptest.NewServer(s.routes())
+
+	return s
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	s.registerAuth(mux)
+	s.registerApp(mux)
+	s.registerTorrents(mux)
+	s.registerSync(mux)
+	s.registerLog(mux)
+	s.registerRSS(mux)
+	s.registerSearch(mux)
+	s.registerTorrentCreator(mux)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.URL.Path]++
+		latency := s.latency
+		hooks := append([]Hook(nil), s.hooks...)
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(latency):
+			}
+		}
+
+		for _, hook := range hooks {
+			if hook(w, r) {
+				return
+			}
+		}
+
+		if r.URL.Path != "/api/v2/auth/login" && !s.authorized(r) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// AddHook adds a hook called before every request, in the order the hooks were added.
+func (s *Server) AddHook(hook Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hooks = append(s.hooks, hook)
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+/*
+FailNext makes the next "times" requests to the endpoint (e.g. /api/v2/torrents/info) fail with the
+given status code and body.
+*/
+func (s *Server) FailNext(endpoint string, times int, status int, body string) {
+	var mu sync.Mutex
+	s.AddHook(func(w http.ResponseWriter, r *http.Request) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if times <= 0 || r.URL.Path != endpoint {
+			return false
+		}
+		times--
+
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+		return true
+	})
+}
+
+// ExpireSessions invalidates all the sessions, the next requests are answered with 403 until the client logs in again.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = make(map[string]bool)
+}
+
+// Requests returns how many requests were made to the endpoint (e.g. /api/v2/auth/login).
+func (s *Server) Requests(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[endpoint]
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.BypassAuth {
+		return true
+	}
+
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sessions[cookie.Value]
+}
+
+func (s *Server) registerAuth(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/v2/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.MaxLoginFailed > 0 && s.loginFailed >= s.MaxLoginFailed {
+			http.Error(w, "Your IP has been banned after too many failed authentication attempts.", http.StatusForbidden)
+			return
+		}
+
+		if r.FormValue("username") != s.Username || r.FormValue("password") != s.Password {
+			s.loginFailed++
+			w.Write([]byte("Fails."))
+			return
+		}
+
+		s.loginFailed = 0
+		sid := randomHex(16)
+		s.sessions[sid] = true
+
+		http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: sid, Path: "/", HttpOnly: true})
+		w.Write([]byte("Ok."))
+	})
+
+	mux.HandleFunc("POST /api/v2/auth/logout", func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie(sessionCookie); err == nil {
+			s.mu.Lock()
+			delete(s.sessions, cookie.Value)
+			s.mu.Unlock()
+		}
+	})
+}
+
+func (s *Server) registerApp(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v2/app/version", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(s.Version))
+	})
+
+	mux.HandleFunc("GET /api/v2/app/webapiVersion", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(s.APIVersion))
+	})
+
+	mux.HandleFunc("GET /api/v2/app/buildInfo", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, qbittorrent.BuildInfo{QT: "6.5.2", LibTorrent: "2.0.9.0", Boost: "1.83.0", OpenSSL: "3.1.3", Bitness: 64})
+	})
+
+	mux.HandleFunc("GET /api/v2/app/defaultSavePath", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("/downloads"))
+	})
+
+	mux.HandleFunc("GET /api/v2/transfer/info", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		state := s.serverState
+		s.mu.Unlock()
+
+		writeJSON(w, qbittorrent.TransferInfoResponse{
+			DLInfoSpeed:       state.DLInfoSpeed,
+			DLInfoData:        state.DLInfoData,
+			UPInfoSpeed:       state.UPInfoSpeed,
+			UPInfoData:        state.UPInfoData,
+			DLRateLimit:       state.DLRateLimit,
+			UPRateLimit:       state.UPRateLimit,
+			DHTNodes:          state.DHTNodes,
+			ConnectionStatus:  state.ConnectionStatus,
+			Queueing:          state.Queueing,
+			UseAltSpeedLimits: state.UseAltSpeedLimits,
+			RefreshInterval:   state.RefreshInterval,
+		})
+	})
+}
+
+// SetServerState changes the global transfer info reported by the server.
+func (s *Server) SetServerState(update func(state *qbittorrent.ServerState)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update(&s.serverState)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// splitList splits a list parameter, ignoring empty items
+func splitList(v, sep string) (results []string) {
+	for _, item := range strings.Split(v, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			results = append(results, item)
+		}
+	}
+	return
+}
@@ -0,0 +1,253 @@
+package qbittest_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+const ubuntuHash = "8c212779b4abde7c6bc608063a0d008b7e40ce32"
+
+// rawClient talks to the server without the qbittorrent package, to test the server itself
+type rawClient struct {
+	t    *testing.T
+	srv  *qbittest.Server
+	http *http.Client
+}
+
+func newRawClient(t *testing.T, srv *qbittest.Server) *rawClient {
+	jar, _ := cookiejar.New(nil)
+	return &rawClient{t: t, srv: srv, http: &http.Client{Jar: jar}}
+}
+
+func (c *rawClient) get(endpoint string, params url.Values) (status int, body string) {
+	c.t.Helper()
+
+	resp, err := c.http.Get(c.srv.URL + endpoint + "?" + params.Encode())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return readResponse(c.t, resp)
+}
+
+func (c *rawClient) post(endpoint string, form url.Values) (status int, body string) {
+	c.t.Helper()
+
+	resp, err := c.http.PostForm(c.srv.URL+endpoint, form)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return readResponse(c.t, resp)
+}
+
+func (c *rawClient) login(password string) (status int, body string) {
+	c.t.Helper()
+
+	return c.post("/api/v2/auth/login", url.Values{"username": {qbittest.DefaultUsername}, "password": {password}})
+}
+
+func readResponse(t *testing.T, resp *http.Response) (status int, body string) {
+	t.Helper()
+
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+func TestLogin(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	client := newRawClient(t, srv)
+
+	if status, _ := client.get("/api/v2/app/version", nil); status != http.StatusForbidden {
+		t.Fatalf("request without session: got status %d, want 403", status)
+	}
+
+	if status, body := client.login("wrong"); status != http.StatusOK || body != "Fails." {
+		t.Fatalf("login with a wrong password: got %d %q, want 200 \"Fails.\"", status, body)
+	}
+
+	if status, body := client.login(qbittest.DefaultPassword); status != http.StatusOK || body != "Ok." {
+		t.Fatalf("login: got %d %q, want 200 \"Ok.\"", status, body)
+	}
+
+	if status, body := client.get("/api/v2/app/version", nil); status != http.StatusOK || body != qbittest.DefaultVersion {
+		t.Fatalf("request with session: got %d %q", status, body)
+	}
+
+	client.post("/api/v2/auth/logout", nil)
+	if status, _ := client.get("/api/v2/app/version", nil); status != http.StatusForbidden {
+		t.Fatalf("request after logout: got status %d, want 403", status)
+	}
+}
+
+func TestLoginBan(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+	srv.MaxLoginFailed = 2
+
+	client := newRawClient(t, srv)
+
+	client.login("wrong")
+	client.login(qbittest.DefaultPassword) // a successful login resets the failure count
+	client.login("wrong")
+	if status, body := client.login("wrong"); status != http.StatusOK || body != "Fails." {
+		t.Fatalf("second failed login: got %d %q, want 200 \"Fails.\"", status, body)
+	}
+
+	if status, _ := client.login(qbittest.DefaultPassword); status != http.StatusForbidden {
+		t.Fatalf("login after %d failures: got status %d, want 403", srv.MaxLoginFailed, status)
+	}
+}
+
+func TestBypassAuth(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+	srv.BypassAuth = true
+
+	client := newRawClient(t, srv)
+
+	if status, _ := client.get("/api/v2/app/webapiVersion", nil); status != http.StatusOK {
+		t.Fatalf("got status %d, want 200", status)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	client := newRawClient(t, srv)
+	client.login(qbittest.DefaultPassword)
+
+	srv.FailNext("/api/v2/app/version", 2, http.StatusBadGateway, "Bad Gateway")
+
+	if status, _ := client.get("/api/v2/app/webapiVersion", nil); status != http.StatusOK {
+		t.Fatalf("other endpoint: got status %d, want 200", status)
+	}
+
+	for i := 0; i < 2; i++ {
+		if status, body := client.get("/api/v2/app/version", nil); status != http.StatusBadGateway || body != "Bad Gateway" {
+			t.Fatalf("request %d: got %d %q, want 502 \"Bad Gateway\"", i+1, status, body)
+		}
+	}
+
+	if status, _ := client.get("/api/v2/app/version", nil); status != http.StatusOK {
+		t.Fatalf("request 3: got status %d, want 200", status)
+	}
+
+	if n := srv.Requests("/api/v2/app/version"); n != 3 {
+		t.Fatalf("got %d requests, want 3", n)
+	}
+}
+
+func TestExpireSessions(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	client := newRawClient(t, srv)
+	other := newRawClient(t, srv)
+	client.login(qbittest.DefaultPassword)
+	other.login(qbittest.DefaultPassword)
+
+	srv.ExpireSessions()
+
+	for _, c := range []*rawClient{client, other} {
+		if status, _ := c.get("/api/v2/app/version", nil); status != http.StatusForbidden {
+			t.Fatalf("expired session: got status %d, want 403", status)
+		}
+	}
+
+	client.login(qbittest.DefaultPassword)
+	if status, _ := client.get("/api/v2/app/version", nil); status != http.StatusOK {
+		t.Fatalf("new session: got status %d, want 200", status)
+	}
+}
+
+// mainData is the part of a /sync/maindata response checked by the tests
+type mainData struct {
+	Rid             int                               `json:"rid"`
+	FullUpdate      bool                              `json:"full_update"`
+	Torrents        map[string]map[string]interface{} `json:"torrents"`
+	TorrentsRemoved []string                          `json:"torrents_removed"`
+	Tags            []string                          `json:"tags"`
+	TagsRemoved     []string                          `json:"tags_removed"`
+	ServerState     map[string]interface{}            `json:"server_state"`
+}
+
+func (c *rawClient) mainData(rid int) (data mainData) {
+	c.t.Helper()
+
+	status, body := c.get("/api/v2/sync/maindata", url.Values{"rid": {strconv.Itoa(rid)}})
+	if status != http.StatusOK {
+		c.t.Fatalf("maindata: got status %d", status)
+	}
+
+	err := json.Unmarshal([]byte(body), &data)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return
+}
+
+func TestSyncMainData(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	client := newRawClient(t, srv)
+	client.login(qbittest.DefaultPassword)
+
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: ubuntuHash, Name: "ubuntu", State: qbittorrent.TorrentStateDownloading})
+
+	full := client.mainData(0)
+	if !full.FullUpdate || full.Rid == 0 {
+		t.Fatalf("first response: got full_update=%v rid=%d", full.FullUpdate, full.Rid)
+	}
+	if name := full.Torrents[ubuntuHash]["name"]; name != "ubuntu" {
+		t.Fatalf("full update: got torrent name %v", name)
+	}
+	if full.ServerState["connection_status"] != string(qbittorrent.Connected) {
+		t.Fatalf("full update: got server state %v", full.ServerState)
+	}
+
+	unchanged := client.mainData(full.Rid)
+	if unchanged.FullUpdate || unchanged.Rid <= full.Rid || len(unchanged.Torrents) != 0 || len(unchanged.ServerState) != 0 {
+		t.Fatalf("response without changes: got %+v", unchanged)
+	}
+
+	srv.UpdateTorrent(ubuntuHash, func(info *qbittorrent.TorrentListResponse) { info.Progress = 0.5 })
+	client.post("/api/v2/torrents/createTags", url.Values{"tags": {"linux"}})
+
+	delta := client.mainData(unchanged.Rid)
+	want := map[string]map[string]interface{}{ubuntuHash: {"progress": 0.5}}
+	if delta.FullUpdate || !reflect.DeepEqual(delta.Torrents, want) {
+		t.Fatalf("delta: got full_update=%v torrents=%v, want only %v", delta.FullUpdate, delta.Torrents, want)
+	}
+	if !reflect.DeepEqual(delta.Tags, []string{"linux"}) {
+		t.Fatalf("delta: got tags %v", delta.Tags)
+	}
+
+	srv.RemoveTorrent(ubuntuHash)
+	client.post("/api/v2/torrents/deleteTags", url.Values{"tags": {"linux"}})
+
+	removed := client.mainData(delta.Rid)
+	if !reflect.DeepEqual(removed.TorrentsRemoved, []string{ubuntuHash}) || !reflect.DeepEqual(removed.TagsRemoved, []string{"linux"}) {
+		t.Fatalf("removal: got %+v", removed)
+	}
+
+	// a rid the server doesn't know, e.g. an old one, gets a full update
+	if stale := client.mainData(full.Rid + 1000); !stale.FullUpdate {
+		t.Fatalf("unknown rid: got full_update=false")
+	}
+}
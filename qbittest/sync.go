@@ -0,0 +1,209 @@
+package qbittest
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// number of responses kept to answer /sync/maindata with a delta
+const maxSyncSnapshots = 16
+
+// syncSnapshot is the main data sent in a /sync/maindata response
+type syncSnapshot struct {
+	torrents    map[string]map[string]interface{}
+	categories  map[string]map[string]interface{}
+	tags        map[string]bool
+	trackers    map[string][]string
+	serverState map[string]interface{}
+}
+
+// peerSnapshot is the peers of a torrent sent in a /sync/torrentPeers response
+type peerSnapshot struct {
+	hash  string
+	peers map[string]map[string]interface{}
+}
+
+// snapshot captures the current main data, the caller must hold s.mu
+func (s *Server) snapshot() *syncSnapshot {
+	snap := &syncSnapshot{
+		torrents:    make(map[string]map[string]interface{}),
+		categories:  make(map[string]map[string]interface{}),
+		tags:        make(map[string]bool),
+		trackers:    make(map[string][]string),
+		serverState: toFields(s.serverState),
+	}
+
+	for hash, t := range s.torrents {
+		fields := toFields(t.info)
+		delete(fields, "hash") // the hash is the key of the torrent
+		snap.torrents[hash] = fields
+
+		for _, tracker := range t.trackers {
+			snap.trackers[tracker] = append(snap.trackers[tracker], hash)
+		}
+	}
+	for _, hashes := range snap.trackers {
+		sort.Strings(hashes)
+	}
+	for name, category := range s.categories {
+		snap.categories[name] = toFields(category)
+	}
+	for tag := range s.tags {
+		snap.tags[tag] = true
+	}
+
+	return snap
+}
+
+func (s *Server) registerSync(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v2/sync/maindata", func(w http.ResponseWriter, r *http.Request) {
+		rid, _ := strconv.Atoi(r.FormValue("rid"))
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		cur := s.snapshot()
+		prev, ok := s.syncSnapshots[rid]
+
+		s.syncRid++
+		s.syncSnapshots[s.syncRid] = cur
+		delete(s.syncSnapshots, s.syncRid-maxSyncSnapshots)
+
+		response := map[string]interface{}{"rid": s.syncRid}
+		if !ok {
+			response["full_update"] = true
+			prev = &syncSnapshot{}
+		}
+
+		torrents := diffItems(prev.torrents, cur.torrents, !ok)
+		if len(torrents) > 0 {
+			response["torrents"] = torrents
+		}
+		if removed := removedKeys(prev.torrents, cur.torrents); len(removed) > 0 {
+			response["torrents_removed"] = removed
+		}
+
+		categories := diffItems(prev.categories, cur.categories, !ok)
+		if len(categories) > 0 {
+			response["categories"] = categories
+		}
+		if removed := removedKeys(prev.categories, cur.categories); len(removed) > 0 {
+			response["categories_removed"] = removed
+		}
+
+		if added := removedKeys(cur.tags, prev.tags); len(added) > 0 {
+			response["tags"] = added
+		}
+		if removed := removedKeys(prev.tags, cur.tags); len(removed) > 0 {
+			response["tags_removed"] = removed
+		}
+
+		trackers := make(map[string][]string)
+		for tracker, hashes := range cur.trackers {
+			if !reflect.DeepEqual(prev.trackers[tracker], hashes) {
+				trackers[tracker] = hashes
+			}
+		}
+		if len(trackers) > 0 {
+			response["trackers"] = trackers
+		}
+		if removed := removedKeys(prev.trackers, cur.trackers); len(removed) > 0 {
+			response["trackers_removed"] = removed
+		}
+
+		if serverState := diffFields(prev.serverState, cur.serverState, !ok); len(serverState) > 0 {
+			response["server_state"] = serverState
+		}
+
+		writeJSON(w, response)
+	})
+
+	mux.HandleFunc("GET /api/v2/sync/torrentPeers", func(w http.ResponseWriter, r *http.Request) {
+		hash := strings.ToLower(r.FormValue("hash"))
+		rid, _ := strconv.Atoi(r.FormValue("rid"))
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		t, ok := s.torrents[hash]
+		if !ok {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+
+		cur := &peerSnapshot{hash: hash, peers: make(map[string]map[string]interface{})}
+		for addr, peer := range t.peers {
+			cur.peers[addr] = toFields(peer)
+		}
+
+		// a rid of another torrent gets a full update too
+		prev, ok := s.peerSnapshots[rid]
+		if ok && prev.hash != hash {
+			ok = false
+		}
+
+		s.peersRid++
+		s.peerSnapshots[s.peersRid] = cur
+		delete(s.peerSnapshots, s.peersRid-maxSyncSnapshots)
+
+		response := map[string]interface{}{"rid": s.peersRid, "show_flags": true}
+		if !ok {
+			response["full_update"] = true
+			prev = &peerSnapshot{}
+		}
+
+		if peers := diffItems(prev.peers, cur.peers, !ok); len(peers) > 0 {
+			response["peers"] = peers
+		}
+		if removed := removedKeys(prev.peers, cur.peers); len(removed) > 0 {
+			response["peers_removed"] = removed
+		}
+
+		writeJSON(w, response)
+	})
+}
+
+// diffItems returns the new items and the changed fields of the existing ones
+func diffItems(prev, cur map[string]map[string]interface{}, full bool) map[string]map[string]interface{} {
+	results := make(map[string]map[string]interface{})
+	for key, fields := range cur {
+		if changed := diffFields(prev[key], fields, full); len(changed) > 0 {
+			results[key] = changed
+		}
+	}
+	return results
+}
+
+// diffFields returns the fields that differ between two objects, or all of them for a full update
+func diffFields(prev, cur map[string]interface{}, full bool) map[string]interface{} {
+	results := make(map[string]interface{})
+	for key, value := range cur {
+		if old, ok := prev[key]; full || !ok || !reflect.DeepEqual(old, value) {
+			results[key] = value
+		}
+	}
+	return results
+}
+
+// removedKeys returns the sorted keys of prev missing from cur
+func removedKeys[V any](prev, cur map[string]V) []string {
+	results := []string{}
+	for key := range prev {
+		if _, ok := cur[key]; !ok {
+			results = append(results, key)
+		}
+	}
+	sort.Strings(results)
+	return results
+}
+
+// toFields converts a struct to its JSON fields
+func toFields(v interface{}) (fields map[string]interface{}) {
+	data, _ := json.Marshal(v)
+	json.Unmarshal(data, &fields)
+	return
+}
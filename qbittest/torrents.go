@@ -0,0 +1,834 @@
+package qbittest
+
+import (
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+)
+
+type torrent struct {
+	info     qbittorrent.TorrentListResponse
+	files    []qbittorrent.TorrentFile
+	trackers []string
+	webSeeds []string
+	peers    map[string]qbittorrent.TorrentPeer
+	data     []byte // content of the .torrent file, empty for torrents added from a URL
+}
+
+// AddTorrent adds a torrent to the server state, missing fields get sensible defaults.
+func (s *Server) AddTorrent(info qbittorrent.TorrentListResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addTorrent(&torrent{info: info})
+}
+
+// UpdateTorrent changes a torrent in place, it returns false if the torrent doesn't exist.
+func (s *Server) UpdateTorrent(hash string, update func(info *qbittorrent.TorrentListResponse)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.torrents[strings.ToLower(hash)]
+	if !ok {
+		return false
+	}
+
+	update(&t.info)
+	return true
+}
+
+// RemoveTorrent removes a torrent from the server state.
+func (s *Server) RemoveTorrent(hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.torrents, strings.ToLower(hash))
+}
+
+// Torrent returns a torrent from the server state.
+func (s *Server) Torrent(hash string) (info qbittorrent.TorrentListResponse, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.torrents[strings.ToLower(hash)]
+	if !ok {
+		return
+	}
+	return t.info, true
+}
+
+// Torrents returns all the torrents of the server state, sorted by hash.
+func (s *Server) Torrents() (results []qbittorrent.TorrentListResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range s.sortedTorrents() {
+		results = append(results, t.info)
+	}
+	return
+}
+
+// SetTorrentFiles sets the files returned by /api/v2/torrents/files for a torrent, and its size in the torrent list
+// like a magnet link receiving its metadata.
+func (s *Server) SetTorrentFiles(hash string, files []qbittorrent.TorrentFile) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.torrents[strings.ToLower(hash)]
+	if !ok {
+		return false
+	}
+
+	t.files = files
+	t.info.Size, t.info.TotalSize = 0, 0
+	for i := range t.files {
+		t.files[i].Index = i
+		t.info.Size += int(t.files[i].Size)
+		t.info.TotalSize += int(t.files[i].Size)
+	}
+	return true
+}
+
+// SetTorrentPeers sets the peers returned by /api/v2/sync/torrentPeers for a torrent, keyed by their address ("ip:port").
+func (s *Server) SetTorrentPeers(hash string, peers map[string]qbittorrent.TorrentPeer) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.torrents[strings.ToLower(hash)]
+	if !ok {
+		return false
+	}
+
+	t.peers = make(map[string]qbittorrent.TorrentPeer, len(peers))
+	for addr, peer := range peers {
+		t.peers[addr] = peer
+	}
+	return true
+}
+
+// addTorrent fills the defaults and stores the torrent, the caller must hold s.mu
+func (s *Server) addTorrent(t *torrent) {
+	info := &t.info
+	info.Hash = strings.ToLower(info.Hash)
+	if info.Name == "" {
+		info.Name = info.Hash
+	}
+	if info.State == "" {
+		info.State = qbittorrent.TorrentStateStalledDL
+	}
+	if info.SavePath == "" {
+		info.SavePath = "/downloads"
+	}
+	if info.ContentPath == "" {
+		info.ContentPath = path.Join(info.SavePath, info.Name)
+	}
+	if info.AddedOn == 0 {
+		info.AddedOn = int(time.Now().Unix())
+	}
+	if info.Category != "" {
+		if _, ok := s.categories[info.Category]; !ok {
+			s.categories[info.Category] = qbittorrent.Category{Name: info.Category}
+		}
+	}
+	for _, tag := range splitList(info.Tags, ",") {
+		s.tags[tag] = true
+	}
+	if info.Tracker != "" && len(t.trackers) == 0 {
+		t.trackers = []string{info.Tracker}
+	}
+
+	s.torrents[info.Hash] = t
+}
+
+// sortedTorrents returns the torrents sorted by hash, the caller must hold s.mu
+func (s *Server) sortedTorrents() (results []*torrent) {
+	for _, t := range s.torrents {
+		results = append(results, t)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].info.Hash < results[j].info.Hash })
+	return
+}
+
+// selectTorrents returns the torrents matching the "hashes" parameter, the caller must hold s.mu
+func (s *Server) selectTorrents(hashes string) (results []*torrent) {
+	if hashes == "all" {
+		return s.sortedTorrents()
+	}
+
+	for _, hash := range splitList(hashes, "|") {
+		if t, ok := s.torrents[strings.ToLower(hash)]; ok {
+			results = append(results, t)
+		}
+	}
+	return
+}
+
+// stoppedStates returns the state names used for stopped torrents by the emulated version
+func (s *Server) stoppedStates() (up, dl qbittorrent.TorrentState) {
+	if versionAtLeast(s.APIVersion, "2.11.0") {
+		return qbittorrent.TorrentStateStoppedUP, qbittorrent.TorrentStateStoppedDL
+	}
+	return qbittorrent.TorrentStatePausedUP, qbittorrent.TorrentStatePausedDL
+}
+
+// since answers 404 when the emulated WebAPI version is older than version, like a server without the endpoint
+func (s *Server) since(version string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !versionAtLeast(s.APIVersion, version) {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// before answers 404 when the emulated WebAPI version is version or newer, like a server where the endpoint was removed
+func (s *Server) before(version string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if versionAtLeast(s.APIVersion, version) {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) registerTorrents(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v2/torrents/info", s.handleTorrentList)
+	mux.HandleFunc("POST /api/v2/torrents/add", s.handleAddTorrent)
+
+	// torrent lookups answering 404 for unknown hashes
+	lookup := func(handler func(w http.ResponseWriter, r *http.Request, t *torrent)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			t, ok := s.torrents[strings.ToLower(r.FormValue("hash"))]
+			if !ok {
+				http.Error(w, "Not Found", http.StatusNotFound)
+				return
+			}
+			handler(w, r, t)
+		}
+	}
+
+	mux.HandleFunc("GET /api/v2/torrents/properties", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		writeJSON(w, qbittorrent.TorrentGenericProperties{
+			SavePath:        t.info.SavePath,
+			TotalUploaded:   int64(t.info.Uploaded),
+			TotalDownloaded: int64(t.info.Downloaded),
+			UpLimit:         int64(t.info.UpLimit),
+			DlLimit:         int64(t.info.DlLimit),
+			SeedingTime:     int64(t.info.SeedingTime),
+			ShareRatio:      t.info.Ratio,
+			AdditionDate:    int64(t.info.AddedOn),
+			CompletionDate:  int64(t.info.CompletionOn),
+			TotalSize:       int64(t.info.TotalSize),
+			IsPrivate:       t.info.IsPrivate,
+		})
+	}))
+
+	mux.HandleFunc("GET /api/v2/torrents/trackers", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		results := []qbittorrent.TorrentTracker{}
+		for i, tracker := range t.trackers {
+			results = append(results, qbittorrent.TorrentTracker{URL: tracker, Tier: i, Status: qbittorrent.TrackerWorking})
+		}
+		writeJSON(w, results)
+	}))
+
+	mux.HandleFunc("GET /api/v2/torrents/export", s.since("2.8.14", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		if t.data == nil {
+			http.Error(w, "Metadata is not available yet", http.StatusConflict)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-bittorrent")
+		w.Write(t.data)
+	})))
+	mux.HandleFunc("GET /api/v2/torrents/webseeds", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		results := []qbittorrent.TorrentSeed{}
+		for _, seed := range t.webSeeds {
+			results = append(results, qbittorrent.TorrentSeed{Url: seed})
+		}
+		writeJSON(w, results)
+	}))
+
+	// web seeds can be edited since qBittorrent 5.1
+	mux.HandleFunc("POST /api/v2/torrents/addWebSeeds", s.since("2.11.3", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		seeds := splitList(r.FormValue("urls"), "|")
+		for _, seed := range seeds {
+			if _, err := url.ParseRequestURI(seed); err != nil {
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+		}
+		for _, seed := range seeds {
+			if !contains(t.webSeeds, seed) {
+				t.webSeeds = append(t.webSeeds, seed)
+			}
+		}
+	})))
+	mux.HandleFunc("POST /api/v2/torrents/editWebSeed", s.since("2.11.3", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		origUrl, newUrl := r.FormValue("origUrl"), r.FormValue("newUrl")
+		if _, err := url.ParseRequestURI(newUrl); err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		index := indexOf(t.webSeeds, origUrl)
+		if index < 0 {
+			http.Error(w, "Conflict", http.StatusConflict)
+			return
+		}
+		if contains(t.webSeeds, newUrl) {
+			t.webSeeds = append(t.webSeeds[:index], t.webSeeds[index+1:]...)
+			return
+		}
+		t.webSeeds[index] = newUrl
+	})))
+	mux.HandleFunc("POST /api/v2/torrents/removeWebSeeds", s.since("2.11.3", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		for _, seed := range splitList(r.FormValue("urls"), "|") {
+			if index := indexOf(t.webSeeds, seed); index >= 0 {
+				t.webSeeds = append(t.webSeeds[:index], t.webSeeds[index+1:]...)
+			}
+		}
+	})))
+
+	mux.HandleFunc("GET /api/v2/torrents/files", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		results := []qbittorrent.TorrentFile{}
+		indexes := splitList(r.FormValue("indexes"), "|")
+		for _, file := range t.files {
+			if len(indexes) == 0 || contains(indexes, strconv.Itoa(file.Index)) {
+				results = append(results, file)
+			}
+		}
+		writeJSON(w, results)
+	}))
+
+	mux.HandleFunc("POST /api/v2/torrents/filePrio", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		priority, err := strconv.Atoi(r.FormValue("priority"))
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		for _, id := range splitList(r.FormValue("id"), "|") {
+			index, err := strconv.Atoi(id)
+			if err != nil || index < 0 || index >= len(t.files) {
+				http.Error(w, "Conflict", http.StatusConflict)
+				return
+			}
+			t.files[index].Priority = qbittorrent.FilePriority(priority)
+		}
+	}))
+
+	mux.HandleFunc("POST /api/v2/torrents/addTrackers", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		for _, tracker := range splitList(r.FormValue("urls"), "\n") {
+			if !contains(t.trackers, tracker) {
+				t.trackers = append(t.trackers, tracker)
+			}
+		}
+	}))
+
+	mux.HandleFunc("POST /api/v2/torrents/editTracker", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		origUrl, newUrl := r.FormValue("origUrl"), r.FormValue("newUrl")
+		if _, err := url.ParseRequestURI(newUrl); err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		index := indexOf(t.trackers, origUrl)
+		if index < 0 || contains(t.trackers, newUrl) {
+			http.Error(w, "Conflict", http.StatusConflict)
+			return
+		}
+		t.trackers[index] = newUrl
+	}))
+
+	mux.HandleFunc("POST /api/v2/torrents/removeTrackers", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		for _, tracker := range splitList(r.FormValue("urls"), "|") {
+			if index := indexOf(t.trackers, tracker); index >= 0 {
+				t.trackers = append(t.trackers[:index], t.trackers[index+1:]...)
+			}
+		}
+	}))
+
+	mux.HandleFunc("POST /api/v2/torrents/rename", lookup(func(w http.ResponseWriter, r *http.Request, t *torrent) {
+		t.info.Name = r.FormValue("name")
+	}))
+
+	// actions on a list of hashes
+	each := func(action func(r *http.Request, t *torrent)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			for _, t := range s.selectTorrents(r.FormValue("hashes")) {
+				action(r, t)
+			}
+		}
+	}
+
+	stop := each(func(r *http.Request, t *torrent) {
+		up, dl := s.stoppedStates()
+		if t.info.Progress >= 1 {
+			t.info.State = up
+		} else {
+			t.info.State = dl
+		}
+		t.info.DlSpeed, t.info.UpSpeed = 0, 0
+	})
+	start := each(func(r *http.Request, t *torrent) {
+		if t.info.Progress >= 1 {
+			t.info.State = qbittorrent.TorrentStateStalledUP
+		} else {
+			t.info.State = qbittorrent.TorrentStateStalledDL
+		}
+	})
+
+	// qBittorrent 5.0 renamed pause/resume to stop/start
+	mux.HandleFunc("POST /api/v2/torrents/pause", s.before("2.11.0", stop))
+	mux.HandleFunc("POST /api/v2/torrents/stop", s.since("2.11.0", stop))
+	mux.HandleFunc("POST /api/v2/torrents/resume", s.before("2.11.0", start))
+	mux.HandleFunc("POST /api/v2/torrents/start", s.since("2.11.0", start))
+
+	mux.HandleFunc("POST /api/v2/torrents/delete", each(func(r *http.Request, t *torrent) {
+		delete(s.torrents, t.info.Hash)
+	}))
+	mux.HandleFunc("POST /api/v2/torrents/recheck", each(func(r *http.Request, t *torrent) {}))
+	mux.HandleFunc("POST /api/v2/torrents/reannounce", each(func(r *http.Request, t *torrent) {}))
+
+	mux.HandleFunc("POST /api/v2/torrents/setForceStart", each(func(r *http.Request, t *torrent) {
+		t.info.ForceStart = r.FormValue("value") == "true"
+	}))
+	mux.HandleFunc("POST /api/v2/torrents/setAutoManagement", each(func(r *http.Request, t *torrent) {
+		t.info.AutoTmm = r.FormValue("enable") == "true"
+	}))
+	mux.HandleFunc("POST /api/v2/torrents/setLocation", each(func(r *http.Request, t *torrent) {
+		t.info.SavePath = r.FormValue("location")
+		t.info.ContentPath = path.Join(t.info.SavePath, t.info.Name)
+	}))
+	mux.HandleFunc("POST /api/v2/torrents/setDownloadLimit", each(func(r *http.Request, t *torrent) {
+		limit, _ := strconv.Atoi(r.FormValue("limit"))
+		t.info.DlLimit = float64(limit)
+	}))
+	mux.HandleFunc("POST /api/v2/torrents/setUploadLimit", each(func(r *http.Request, t *torrent) {
+		t.info.UpLimit, _ = strconv.Atoi(r.FormValue("limit"))
+	}))
+
+	mux.HandleFunc("POST /api/v2/torrents/setCategory", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		category := r.FormValue("category")
+		if _, ok := s.categories[category]; category != "" && !ok {
+			http.Error(w, "Conflict", http.StatusConflict)
+			return
+		}
+		for _, t := range s.selectTorrents(r.FormValue("hashes")) {
+			t.info.Category = category
+		}
+	})
+
+	s.registerCategories(mux)
+	s.registerTags(mux, each)
+}
+
+func (s *Server) registerCategories(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v2/torrents/categories", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		writeJSON(w, s.categories)
+	})
+
+	mux.HandleFunc("POST /api/v2/torrents/createCategory", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		name := r.FormValue("category")
+		if _, ok := s.categories[name]; ok || name == "" {
+			http.Error(w, "Conflict", http.StatusConflict)
+			return
+		}
+		s.categories[name] = qbittorrent.Category{Name: name, SavePath: r.FormValue("savePath")}
+	})
+
+	mux.HandleFunc("POST /api/v2/torrents/editCategory", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		name := r.FormValue("category")
+		if _, ok := s.categories[name]; !ok {
+			http.Error(w, "Conflict", http.StatusConflict)
+			return
+		}
+		s.categories[name] = qbittorrent.Category{Name: name, SavePath: r.FormValue("savePath")}
+	})
+
+	mux.HandleFunc("POST /api/v2/torrents/removeCategories", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, name := range splitList(r.FormValue("categories"), "\n") {
+			delete(s.categories, name)
+			for _, t := range s.torrents {
+				if t.info.Category == name {
+					t.info.Category = ""
+				}
+			}
+		}
+	})
+}
+
+func (s *Server) registerTags(mux *http.ServeMux, each func(action func(r *http.Request, t *torrent)) http.HandlerFunc) {
+	mux.HandleFunc("GET /api/v2/torrents/tags", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		results := []string{}
+		for tag := range s.tags {
+			results = append(results, tag)
+		}
+		sort.Strings(results)
+		writeJSON(w, results)
+	})
+
+	mux.HandleFunc("POST /api/v2/torrents/createTags", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, tag := range splitList(r.FormValue("tags"), ",") {
+			s.tags[tag] = true
+		}
+	})
+
+	mux.HandleFunc("POST /api/v2/torrents/deleteTags", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, tag := range splitList(r.FormValue("tags"), ",") {
+			delete(s.tags, tag)
+			for _, t := range s.torrents {
+				t.info.Tags = strings.Join(remove(splitList(t.info.Tags, ","), tag), ", ")
+			}
+		}
+	})
+
+	mux.HandleFunc("POST /api/v2/torrents/addTags", each(func(r *http.Request, t *torrent) {
+		tags := splitList(t.info.Tags, ",")
+		for _, tag := range splitList(r.FormValue("tags"), ",") {
+			s.tags[tag] = true
+			if !contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		sort.Strings(tags)
+		t.info.Tags = strings.Join(tags, ", ")
+	}))
+
+	mux.HandleFunc("POST /api/v2/torrents/removeTags", each(func(r *http.Request, t *torrent) {
+		tags := splitList(t.info.Tags, ",")
+		for _, tag := range splitList(r.FormValue("tags"), ",") {
+			tags = remove(tags, tag)
+		}
+		t.info.Tags = strings.Join(tags, ", ")
+	}))
+}
+
+func (s *Server) handleTorrentList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	filter := qbittorrent.Filters(query.Get("filter"))
+	if !s.knownFilter(filter) {
+		// like qBittorrent, a filter the server doesn't know lists every torrent
+		filter = qbittorrent.FilterAll
+	}
+	hashes := splitList(strings.ToLower(query.Get("hashes")), "|")
+
+	results := []qbittorrent.TorrentListResponse{}
+	for _, t := range s.sortedTorrents() {
+		if filter != "" && !matchFilter(filter, t.info) {
+			continue
+		}
+		if query.Has("category") && t.info.Category != query.Get("category") {
+			continue
+		}
+		if query.Has("tag") {
+			tags := splitList(t.info.Tags, ",")
+			if tag := query.Get("tag"); (tag == "" && len(tags) > 0) || (tag != "" && !contains(tags, tag)) {
+				continue
+			}
+		}
+		if len(hashes) > 0 && !contains(hashes, t.info.Hash) {
+			continue
+		}
+		results = append(results, t.info)
+	}
+
+	if query.Get("reverse") == "true" {
+		for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+			results[i], results[j] = results[j], results[i]
+		}
+	}
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if offset < 0 {
+		offset += len(results)
+	}
+	if offset > 0 {
+		results = results[min(offset, len(results)):]
+	}
+	if limit, _ := strconv.Atoi(query.Get("limit")); limit > 0 && limit < len(results) {
+		results = results[:limit]
+	}
+
+	writeJSON(w, results)
+}
+
+func (s *Server) handleAddTorrent(w http.ResponseWriter, r *http.Request) {
+	type source struct {
+		name  string
+		hash  string
+		data  []byte
+		size  int64
+		files []qbittorrent.TorrentFile
+	}
+	var sources []source
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		err := r.ParseMultipartForm(32 << 20)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		for _, header := range r.MultipartForm.File["torrents"] {
+			file, err := header.Open()
+			if err != nil {
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+			data, err := io.ReadAll(file)
+			file.Close()
+			if err != nil {
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+
+			meta, err := metainfo.Parse(data)
+			if err != nil {
+				http.Error(w, "Torrent file is not valid", http.StatusUnsupportedMediaType)
+				return
+			}
+
+			src := source{name: meta.Info.Name, hash: meta.Hash(), data: data, size: meta.Info.TotalLength()}
+			for _, f := range meta.Info.Files {
+				if f.IsPadding() {
+					continue
+				}
+				name := path.Join(f.Path...)
+				if !meta.Info.SingleFile {
+					name = path.Join(meta.Info.Name, name)
+				}
+				src.files = append(src.files, qbittorrent.TorrentFile{
+					Index:    len(src.files),
+					Name:     name,
+					Size:     f.Length,
+					Priority: qbittorrent.FilePriorityNormal,
+				})
+			}
+			sources = append(sources, src)
+		}
+	}
+
+	for _, link := range splitList(r.FormValue("urls"), "\n") {
+		name, hash := parseMagnet(link)
+		if hash == "" {
+			sum := sha1.Sum([]byte(link))
+			hash = hex.EncodeToString(sum[:])
+			name = path.Base(link)
+		}
+		sources = append(sources, source{name: name, hash: hash})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := 0
+	for _, src := range sources {
+		if _, ok := s.torrents[src.hash]; ok {
+			continue
+		}
+
+		info := qbittorrent.TorrentListResponse{
+			Hash:        src.hash,
+			Name:        src.name,
+			SavePath:    r.FormValue("savepath"),
+			Category:    r.FormValue("category"),
+			Tags:        r.FormValue("tags"),
+			AutoTmm:     r.FormValue("autoTMM") == "true",
+			SeqDL:       r.FormValue("sequentialDownload") == "true",
+			FLPiecePrio: r.FormValue("firstLastPiecePrio") == "true",
+			ForceStart:  r.FormValue("forced") == "true",
+			Size:        int(src.size),
+			TotalSize:   int(src.size),
+		}
+		if rename := r.FormValue("rename"); rename != "" {
+			info.Name = rename
+		}
+		// torrents added from a file have their metadata, so they stop right away with the MetadataReceived condition
+		stopCondition := qbittorrent.StopCondition(r.FormValue("stopCondition"))
+		if r.FormValue("paused") == "true" || r.FormValue("stopped") == "true" ||
+			(src.data != nil && stopCondition == qbittorrent.StopConditionMetadataReceived && versionAtLeast(s.APIVersion, "2.8.14")) {
+			_, info.State = s.stoppedStates()
+		} else if src.data == nil {
+			info.State = qbittorrent.TorrentStateMetaDL
+		}
+		info.UpLimit, _ = strconv.Atoi(r.FormValue("upLimit"))
+		dlLimit, _ := strconv.Atoi(r.FormValue("dlLimit"))
+		info.DlLimit = float64(dlLimit)
+
+		s.addTorrent(&torrent{info: info, data: src.data, files: src.files})
+		added++
+	}
+
+	if added == 0 {
+		w.Write([]byte("Fails."))
+		return
+	}
+	w.Write([]byte("Ok."))
+}
+
+// knownFilter reports whether the emulated version knows the filter, qBittorrent 5.0 renamed paused/resumed to stopped/running
+func (s *Server) knownFilter(filter qbittorrent.Filters) bool {
+	switch filter {
+	case qbittorrent.FilterPaused, qbittorrent.FilterResumed:
+		return !versionAtLeast(s.APIVersion, "2.11.0")
+	case qbittorrent.FilterStopped, qbittorrent.FilterRunning:
+		return versionAtLeast(s.APIVersion, "2.11.0")
+	}
+	return true
+}
+
+func matchFilter(filter qbittorrent.Filters, info qbittorrent.TorrentListResponse) bool {
+	state := info.State
+	stopped := state.IsStopped()
+	uploading := state == qbittorrent.TorrentStateUploading || state == qbittorrent.TorrentStateStalledUP || state == qbittorrent.TorrentStateCheckingUP ||
+		state == qbittorrent.TorrentStateQueuedUP || state == qbittorrent.TorrentStateForcedUP
+
+	switch filter {
+	case qbittorrent.FilterAll:
+		return true
+	case qbittorrent.FilterDownloading:
+		return strings.HasSuffix(string(state), "DL") || state == qbittorrent.TorrentStateDownloading
+	case qbittorrent.FilterSeeding:
+		return uploading
+	case qbittorrent.FilterCompleted:
+		return state.IsComplete()
+	case qbittorrent.FilterPaused, qbittorrent.FilterStopped:
+		return stopped
+	case qbittorrent.FilterResumed, qbittorrent.FilterRunning:
+		return !stopped
+	case qbittorrent.FilterActive:
+		return info.DlSpeed > 0 || info.UpSpeed > 0
+	case qbittorrent.FilterInactive:
+		return info.DlSpeed == 0 && info.UpSpeed == 0
+	case qbittorrent.FilterStalled:
+		return state == qbittorrent.TorrentStateStalledUP || state == qbittorrent.TorrentStateStalledDL
+	case qbittorrent.FilterStalledUploading:
+		return state == qbittorrent.TorrentStateStalledUP
+	case qbittorrent.FilterStalledDownloading:
+		return state == qbittorrent.TorrentStateStalledDL
+	case qbittorrent.FilterErrored:
+		return state.IsErrored()
+	case qbittorrent.FilterChecking:
+		return state.IsChecking()
+	case qbittorrent.FilterMoving:
+		return state == qbittorrent.TorrentStateMoving
+	}
+	return false
+}
+
+// parseMagnet returns the display name and the lower case hex v1 info hash of a magnet link
+func parseMagnet(link string) (name, hash string) {
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme != "magnet" {
+		return
+	}
+
+	query := u.Query()
+	name = query.Get("dn")
+	for _, xt := range query["xt"] {
+		// a v2 only torrent is identified by its SHA-256 info hash truncated to 20 bytes
+		if v, ok := strings.CutPrefix(xt, "urn:btmh:1220"); ok && len(v) == 64 && hash == "" {
+			hash = strings.ToLower(v[:40])
+			continue
+		}
+
+		v, ok := strings.CutPrefix(xt, "urn:btih:")
+		if !ok {
+			continue
+		}
+		switch len(v) {
+		case 40:
+			hash = strings.ToLower(v)
+		case 32:
+			if b, err := base32.StdEncoding.DecodeString(strings.ToUpper(v)); err == nil {
+				hash = hex.EncodeToString(b)
+			}
+		}
+	}
+	if name == "" {
+		name = hash
+	}
+	return
+}
+
+func contains(list []string, v string) bool {
+	return indexOf(list, v) >= 0
+}
+
+func indexOf(list []string, v string) int {
+	for i, item := range list {
+		if item == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func remove(list []string, v string) []string {
+	if i := indexOf(list, v); i >= 0 {
+		return append(list[:i], list[i+1:]...)
+	}
+	return list
+}
+
+// versionAtLeast compares two dotted version numbers
+func versionAtLeast(version, required string) bool {
+	a := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	b := strings.Split(required, ".")
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x, _ = strconv.Atoi(a[i])
+		}
+		if i < len(b) {
+			y, _ = strconv.Atoi(b[i])
+		}
+		if x != y {
+			return x > y
+		}
+	}
+	return true
+}
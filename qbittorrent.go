@@ -2,145 +2,230 @@ package qbittorrent
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
-	"net/http/cookiejar"
 	"net/url"
-	"os"
-	"path"
-
-	"golang.org/x/net/publicsuffix"
+	"sort"
+	"sync"
+	"time"
 )
 
+/*
+Client is a qBittorrent WebUI API client, create it with [NewClient].
+
+A Client is safe for concurrent use by multiple goroutines, as long as ServerURL and Jar are not
+changed after it's created.
+
+When a request is answered with 403 Forbidden after [Client.Login] succeeded, or when credentials were
+given with [WithCredentials], the client logs in again and retries the request once. Concurrent requests hitting an expired session share a single login.
+*/
 type Client struct {
-	ServerURL string
-	http      *http.Client
-	Jar       http.CookieJar
-	username  string
-	password  string
+	ServerURL     string
+	http          *http.Client
+	Jar           http.CookieJar
+	userAgent     string
+	headers       http.Header
+	retry         *RetryPolicy
+	sessions      SessionStore    // restores and saves the session on login, nil to always log in
+	sessionErrors func(err error) // reports the session store errors that don't fail the login, may be nil
+	authBypass    bool            // the server doesn't require logging in
+	searchSlots   chan struct{}   // limits the number of searches run by Search
+	optionsErr    error           // invalid combination of options given to NewClient, returned by every request
+
+	mu             sync.Mutex         // guards the fields below
+	credentials    CredentialProvider // used to log in again, nil when not logged in
+	session        uint64             // incremented by every successful login
+	sessionExpires time.Time          // expiry of the session cookie, zero when unknown
+	loginCall      *loginCall         // login in progress started by relogin
+	caps           *Capabilities      // nil until fetched by CapabilitiesCtx
+	capsSession    uint64             // session the capabilities were fetched in, they are fetched again after a login
 }
 
-func NewClient(serverURL string) *Client {
-	client := &Client{ServerURL: serverURL}
-	client.Jar, _ = cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
-	client.http = &http.Client{Jar: client.Jar}
+/*
+NewClient returns a client for the qBittorrent WebUI at serverURL.
+
+The TLS options ([WithRootCAs], [WithPinnedCertificate] and [WithInsecureSkipVerify]) are applied to a copy of
+the transport. When they are combined with [WithTransport] or [WithHTTPClient] using a transport that is not an
+*http.Transport, the certificate checks can't be applied: [Client.Login] and every request return an error
+matching [ErrInvalidOptions] instead of connecting without them.
+
+# Example
+
+	client := qbittorrent.NewClient("https://nas.local:8080",
+	 qbittorrent.WithTimeout(30*time.Second),
+	 qbittorrent.WithPinnedCertificate(fingerprint),
+	 qbittorrent.WithHeader("X-Api-Key", "secret"),
+	)
+*/
+func NewClient(serverURL string, opts ...ClientOption) *Client {
+	cfg := &clientConfig{userAgent: defaultUserAgent, headers: http.Header{}, retry: DefaultRetryPolicy()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	client := &Client{ServerURL: serverURL, searchSlots: make(chan struct{}, maxRunningSearches)}
+	client.optionsErr = cfg.apply(client)
+
 	return client
 }
 
-func (c *Client) getReq(endpoint string, params *url.Values) (body []byte, err error) {
-	u, err := url.Parse(c.ServerURL)
+// newRequest creates a request with the headers configured for the client
+func (c *Client) newRequest(ctx context.Context, method, url string, body io.Reader) (req *http.Request, err error) {
+	req, err = http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		fmt.Println("Error parsing base URL:", err)
 		return
 	}
 
-	u.Path, err = url.JoinPath(u.Path, endpoint)
-	if err != nil {
-		return
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
 	}
+	req.Header.Set("User-Agent", c.userAgent)
 
-	if params != nil {
-		u.RawQuery = params.Encode()
+	return
+}
+
+// requestBody returns a new reader of the request body, it's called for every attempt so retries resend the full body.
+// contentLength is -1 when unknown
+type requestBody func() (reader io.Reader, contentType string, contentLength int64, err error)
+
+func (c *Client) getReq(ctx context.Context, endpoint string, params *url.Values) (body []byte, err error) {
+	return c.do(ctx, "GET", endpoint, params, nil)
+}
+
+func (c *Client) postReq(ctx context.Context, endpoint string, form *url.Values) (body []byte, err error) {
+	var payload []byte
+	if form != nil {
+		payload = []byte(form.Encode())
 	}
 
-	req, err := http.NewRequest("GET", u.String(), nil)
+	return c.do(ctx, "POST", endpoint, nil, func() (io.Reader, string, int64, error) {
+		return bytes.NewReader(payload), "application/x-www-form-urlencoded", int64(len(payload)), nil
+	})
+}
+
+// postMultipart streams a multipart form through a pipe, so the files are never fully loaded in memory
+func (c *Client) postMultipart(ctx context.Context, endpoint string, fields map[string]string, files []multipartFile) (body []byte, err error) {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	// the length is computed first as qBittorrent doesn't accept chunked requests
+	length, err := multipartLength(boundary, fields, files)
 	if err != nil {
 		return
 	}
-	req.Header.Set("User-Agent", "qbittorrent-webapi-go v1.0")
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return
+	return c.do(ctx, "POST", endpoint, nil, func() (io.Reader, string, int64, error) {
+		reader, pipe := io.Pipe()
+		writer := multipart.NewWriter(pipe)
+		writer.SetBoundary(boundary)
+
+		go func() {
+			pipe.CloseWithError(writeMultipart(writer, fields, files))
+		}()
+
+		return reader, writer.FormDataContentType(), length, nil
+	})
+}
+
+// do sends a request, when the session has expired it logs in again and retries the request once
+func (c *Client) do(ctx context.Context, method, endpoint string, params *url.Values, newBody requestBody) (body []byte, err error) {
+	if c.optionsErr != nil {
+		return nil, c.optionsErr
 	}
-	defer resp.Body.Close()
 
-	body, err = io.ReadAll(resp.Body)
+	reqURL, err := c.endpointURL(endpoint, params)
 	if err != nil {
 		return
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		// try to login again
-		if c.username != "" && c.password != "" && resp.StatusCode == http.StatusForbidden {
-			err = c.Login(c.username, c.password)
-			if err != nil {
-				return
-			}
-
-			return c.getReq(endpoint, params)
-		}
+	session := c.currentSession()
 
-		err = fmt.Errorf("%d: %s", resp.StatusCode, string(body))
+	body, err = c.sendWithRetry(ctx, method, reqURL, endpoint, newBody)
+	if !errors.Is(err, ErrForbidden) || !c.hasCredentials() {
 		return
 	}
 
-	return body, nil
-}
-
-func (c *Client) postReq(endpoint string, form *url.Values) (body []byte, err error) {
-	fullUrl, err := url.JoinPath(c.ServerURL, endpoint)
+	err = c.relogin(ctx, session)
 	if err != nil {
-		return
+		return nil, fmt.Errorf("qbittorrent: logging in again: %w", err)
 	}
 
-	var payload io.Reader = nil
-	if form != nil {
-		payload = bytes.NewReader([]byte(form.Encode()))
+	body, err = c.sendWithRetry(ctx, method, reqURL, endpoint, newBody)
+	if errors.Is(err, ErrForbidden) {
+		return nil, fmt.Errorf("%w: %w", ErrSessionRejected, err)
 	}
 
-	req, err := http.NewRequest("POST", fullUrl, payload)
+	return
+}
+
+// endpointURL returns the full URL of an API endpoint
+func (c *Client) endpointURL(endpoint string, params *url.Values) (string, error) {
+	u, err := url.Parse(c.ServerURL)
 	if err != nil {
-		return
+		return "", err
 	}
 
-	req.Header.Set("User-Agent", "qbittorrent-webapi-go v1.0")
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := c.http.Do(req)
+	u.Path, err = url.JoinPath(u.Path, endpoint)
 	if err != nil {
-		return
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	body, err = io.ReadAll(resp.Body)
-	if err != nil {
-		return
+	if params != nil {
+		u.RawQuery = params.Encode()
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		// try to login again
-		if c.username != "" && c.password != "" && resp.StatusCode == http.StatusForbidden {
-			err = c.Login(c.username, c.password)
-			if err != nil {
-				return
-			}
-
-			return c.postReq(endpoint, form)
+	return u.String(), nil
+}
+
+// sendWithRetry sends a request until it succeeds or the retry policy of the client gives up
+func (c *Client) sendWithRetry(ctx context.Context, method, url, endpoint string, newBody requestBody) (body []byte, err error) {
+	for attempt := 1; ; attempt++ {
+		body, err = c.send(ctx, method, url, endpoint, newBody)
+		if err == nil || ctx.Err() != nil || !c.retry.shouldRetry(method, attempt, err) {
+			return
 		}
 
-		err = fmt.Errorf("%d: %s", resp.StatusCode, string(body))
-		return
+		timer := time.NewTimer(c.retry.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
 	}
-
-	return
 }
 
-func (c *Client) postMultipart(endpoint string, buffer bytes.Buffer, contentType string) (body []byte, err error) {
-	fullUrl, err := url.JoinPath(c.ServerURL, endpoint)
-	if err != nil {
-		return
+// send sends a single request and returns the response body
+func (c *Client) send(ctx context.Context, method, url, endpoint string, newBody requestBody) (body []byte, err error) {
+	var reader io.Reader
+	var contentType string
+	var contentLength int64
+	if newBody != nil {
+		reader, contentType, contentLength, err = newBody()
+		if err != nil {
+			return
+		}
 	}
 
-	req, err := http.NewRequest("POST", fullUrl, &buffer)
+	req, err := c.newRequest(ctx, method, url, reader)
 	if err != nil {
-		return nil, err
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+		return
 	}
 
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("User-Agent", "qbittorrent-webapi-go v1.0")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if reader != nil && contentLength >= 0 {
+		req.ContentLength = contentLength
+	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -154,39 +239,99 @@ func (c *Client) postMultipart(endpoint string, buffer bytes.Buffer, contentType
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		// try to login again
-		if c.username != "" && c.password != "" && resp.StatusCode == http.StatusForbidden {
-			err = c.Login(c.username, c.password)
-			if err != nil {
-				return
-			}
-
-			return c.postMultipart(endpoint, buffer, contentType)
+		return nil, newAPIError(req, resp, endpoint, body)
+	}
+
+	return
+}
+
+// multipartFile is a file sent by postMultipart
+type multipartFile struct {
+	field string
+	name  string
+	size  int64
+	open  func() (io.ReadCloser, error) // called every time the request is sent
+}
+
+// writeMultipart writes the fields then the files, and closes writer
+func writeMultipart(writer *multipart.Writer, fields map[string]string, files []multipartFile) (err error) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		err = writer.WriteField(key, fields[key])
+		if err != nil {
+			return
 		}
+	}
 
-		err = fmt.Errorf("%d: %s", resp.StatusCode, string(body))
-		return
+	for _, file := range files {
+		err = writeMultipartFile(writer, file)
+		if err != nil {
+			return
+		}
 	}
 
-	return
+	return writer.Close()
 }
 
-func (c *Client) addFileToWriter(writer *multipart.Writer, filePath string) (err error) {
-	formWriter, err := writer.CreateFormFile("torrents", path.Base(filePath))
+func writeMultipartFile(writer *multipart.Writer, file multipartFile) (err error) {
+	part, err := writer.CreateFormFile(file.field, file.name)
 	if err != nil {
 		return
 	}
 
-	file, err := os.Open(filePath)
+	reader, err := file.open()
 	if err != nil {
 		return
 	}
-	defer file.Close()
+	defer reader.Close()
 
-	_, err = io.Copy(formWriter, file)
+	// the content length was computed from file.size
+	n, err := io.Copy(part, io.LimitReader(reader, file.size+1))
 	if err != nil {
 		return
 	}
+	if n != file.size {
+		return fmt.Errorf("qbittorrent: %s changed while it was sent", file.name)
+	}
 
 	return
 }
+
+// multipartLength returns the length of the body written by writeMultipart
+func multipartLength(boundary string, fields map[string]string, files []multipartFile) (length int64, err error) {
+	counter := &countingWriter{}
+	writer := multipart.NewWriter(counter)
+	writer.SetBoundary(boundary)
+
+	for key, value := range fields {
+		err = writer.WriteField(key, value)
+		if err != nil {
+			return
+		}
+	}
+
+	for _, file := range files {
+		_, err = writer.CreateFormFile(file.field, file.name)
+		if err != nil {
+			return
+		}
+		counter.n += file.size
+	}
+
+	err = writer.Close()
+	return counter.n, err
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,203 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+const (
+	loginEndpoint    = "/api/v2/auth/login"
+	torrentsEndpoint = "/api/v2/torrents/info"
+	ubuntuHash       = "8c212779b4abde7c6bc608063a0d008b7e40ce32"
+)
+
+// newTestClient starts a fake server and returns a client logged in to it
+func newTestClient(t *testing.T, opts ...qbittorrent.ClientOption) (*qbittest.Server, *qbittorrent.Client) {
+	t.Helper()
+
+	return newTestClientVersion(t, qbittest.DefaultAPIVersion, opts...)
+}
+
+// newTestClientVersion is like newTestClient with a server emulating the given WebAPI version
+func newTestClientVersion(t *testing.T, apiVersion string, opts ...qbittorrent.ClientOption) (*qbittest.Server, *qbittorrent.Client) {
+	t.Helper()
+
+	srv := qbittest.NewServer()
+	srv.APIVersion = apiVersion
+	t.Cleanup(srv.Close)
+
+	client := qbittorrent.NewClient(srv.URL, opts...)
+	err := client.Login(qbittest.DefaultUsername, qbittest.DefaultPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return srv, client
+}
+
+// testTorrent returns a valid single file .torrent
+func testTorrent(t *testing.T, name string, length int64) []byte {
+	t.Helper()
+
+	pieces := (length + 16<<10 - 1) / (16 << 10)
+	data, err := metainfo.Encode(map[string]any{
+		"announce": "udp://tracker.example.com:1337",
+		"info": map[string]any{
+			"name":         name,
+			"piece length": int64(16 << 10),
+			"length":       length,
+			"pieces":       strings.Repeat("p", int(20*pieces)),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+// concurrently runs f in n goroutines and returns their errors
+func concurrently(n int, f func() error) []error {
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = f()
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// holdLogins delays the logins until the server received n torrent list requests since the hook was added,
+// so all of them are rejected before the session is renewed
+func holdLogins(srv *qbittest.Server, n int) {
+	start := srv.Requests(torrentsEndpoint)
+	srv.AddHook(func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == loginEndpoint {
+			for srv.Requests(torrentsEndpoint)-start < n {
+				time.Sleep(time.Millisecond)
+			}
+			// let the last rejected requests reach relogin
+			time.Sleep(50 * time.Millisecond)
+		}
+		return false
+	})
+}
+
+func TestConcurrentRequestsShareLogin(t *testing.T) {
+	const n = 20
+
+	srv, client := newTestClient(t)
+	logins := srv.Requests(loginEndpoint)
+
+	srv.ExpireSessions()
+	holdLogins(srv, n)
+
+	errs := concurrently(n, func() error {
+		_, err := client.GetTorrentList(nil)
+		return err
+	})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := srv.Requests(loginEndpoint) - logins; got != 1 {
+		t.Fatalf("got %d logins for %d concurrent requests, want 1", got, n)
+	}
+}
+
+func TestSessionRejectedAfterRelogin(t *testing.T) {
+	srv, client := newTestClient(t)
+	logins := srv.Requests(loginEndpoint)
+	requests := srv.Requests(torrentsEndpoint)
+
+	// the request and its retry after logging in again are both rejected
+	srv.FailNext(torrentsEndpoint, 2, http.StatusForbidden, "Forbidden")
+
+	_, err := client.GetTorrentList(nil)
+	if !errors.Is(err, qbittorrent.ErrSessionRejected) || !errors.Is(err, qbittorrent.ErrForbidden) {
+		t.Fatalf("got error %v, want ErrSessionRejected", err)
+	}
+
+	if got := srv.Requests(loginEndpoint) - logins; got != 1 {
+		t.Fatalf("got %d logins, want 1", got)
+	}
+	if got := srv.Requests(torrentsEndpoint) - requests; got != 2 {
+		t.Fatalf("got %d requests, want 2", got)
+	}
+
+	// the client still works once the server accepts the session
+	if _, err := client.GetTorrentList(nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloginFailed(t *testing.T) {
+	const n = 10
+
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	var passwordChanged atomic.Bool
+	client := qbittorrent.NewClient(srv.URL)
+	err := client.LoginWith(qbittorrent.CredentialProviderFunc(func(context.Context) (qbittorrent.Credentials, error) {
+		if passwordChanged.Load() {
+			return qbittorrent.Credentials{Username: qbittest.DefaultUsername, Password: "changed"}, nil
+		}
+		return qbittorrent.Credentials{Username: qbittest.DefaultUsername, Password: qbittest.DefaultPassword}, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logins := srv.Requests(loginEndpoint)
+
+	passwordChanged.Store(true)
+	srv.ExpireSessions()
+	holdLogins(srv, n)
+
+	errs := concurrently(n, func() error {
+		_, err := client.GetTorrentList(nil)
+		return err
+	})
+	for _, err := range errs {
+		if !errors.Is(err, qbittorrent.ErrLoginFailed) {
+			t.Fatalf("got error %v, want ErrLoginFailed", err)
+		}
+	}
+
+	if got := srv.Requests(loginEndpoint) - logins; got != 1 {
+		t.Fatalf("got %d logins for %d concurrent requests, want 1", got, n)
+	}
+}
+
+func TestForbiddenWithoutLogin(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	client := qbittorrent.NewClient(srv.URL)
+
+	_, err := client.GetTorrentList(nil)
+	if !errors.Is(err, qbittorrent.ErrForbidden) || errors.Is(err, qbittorrent.ErrSessionRejected) {
+		t.Fatalf("got error %v, want ErrForbidden", err)
+	}
+	if got := srv.Requests(loginEndpoint); got != 0 {
+		t.Fatalf("got %d logins without credentials, want 0", got)
+	}
+}
@@ -0,0 +1,85 @@
+package qbittorrent
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+/*
+RetryPolicy decides which failed requests are sent again and how long to wait between the attempts.
+
+A request is retried when the connection fails (e.g. connection refused or reset while qBittorrent restarts),
+or when the response status code is one of RetryableStatusCodes (e.g. 502 from a reverse proxy).
+GET requests are always retried, POST requests change the state of qBittorrent and are only retried when RetryPOST is set.
+
+The wait before the attempt n+1 is InitialBackoff * Multiplier^(n-1), limited to MaxBackoff,
+and randomly changed by up to ±Jitter of its value.
+*/
+type RetryPolicy struct {
+	MaxAttempts          int           // Total number of attempts including the first one, 1 or less disables retries
+	InitialBackoff       time.Duration // Wait before the second attempt
+	MaxBackoff           time.Duration // Maximum wait between two attempts, 0 means no limit
+	Multiplier           float64       // Factor applied to the wait after every attempt, values below 1 are treated as 1
+	Jitter               float64       // Fraction of the wait that is randomized, between 0 and 1
+	RetryableStatusCodes []int         // Status codes that are retried
+	RetryPOST            bool          // Retry POST requests too, only enable it if sending the same request twice is fine for you
+}
+
+// DefaultRetryPolicy returns the policy used by [NewClient]: 3 attempts of GET requests, waiting 250ms then 500ms (±20%),
+// on connection errors and on 502, 503 and 504 responses.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       250 * time.Millisecond,
+		MaxBackoff:           5 * time.Second,
+		Multiplier:           2,
+		Jitter:               0.2,
+		RetryableStatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// shouldRetry reports whether a request that failed with err after the given attempt is sent again
+func (p *RetryPolicy) shouldRetry(method string, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	if method != http.MethodGet && !p.RetryPOST {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return slices.Contains(p.RetryableStatusCodes, apiErr.StatusCode)
+	}
+
+	// *url.Error implements net.Error too, look at the error it wraps so TLS verification errors are not retried
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns the wait after the given attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := math.Max(p.Multiplier, 1)
+	wait := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 {
+		wait = math.Min(wait, float64(p.MaxBackoff))
+	}
+
+	jitter := math.Min(math.Max(p.Jitter, 0), 1)
+	wait += wait * jitter * (rand.Float64()*2 - 1)
+
+	return time.Duration(wait)
+}
@@ -0,0 +1,209 @@
+package qbittorrent_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+const addEndpoint = "/api/v2/torrents/add"
+
+// fastRetryPolicy is the default policy without waiting between the attempts
+func fastRetryPolicy() *qbittorrent.RetryPolicy {
+	policy := qbittorrent.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = time.Millisecond
+	return policy
+}
+
+func TestRetryGET(t *testing.T) {
+	srv, client := newTestClient(t, qbittorrent.WithRetryPolicy(fastRetryPolicy()))
+
+	srv.FailNext(torrentsEndpoint, 2, http.StatusBadGateway, "Bad Gateway")
+	start := srv.Requests(torrentsEndpoint)
+
+	if _, err := client.GetTorrentList(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Requests(torrentsEndpoint) - start; got != 3 {
+		t.Fatalf("got %d attempts, want 3", got)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	srv, client := newTestClient(t, qbittorrent.WithRetryPolicy(fastRetryPolicy()))
+
+	srv.FailNext(torrentsEndpoint, 3, http.StatusServiceUnavailable, "Service Unavailable")
+	start := srv.Requests(torrentsEndpoint)
+
+	_, err := client.GetTorrentList(nil)
+	var apiErr *qbittorrent.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("got error %v, want a 503 APIError", err)
+	}
+	if got := srv.Requests(torrentsEndpoint) - start; got != 3 {
+		t.Fatalf("got %d attempts, want 3", got)
+	}
+}
+
+func TestRetryStatusCodes(t *testing.T) {
+	srv, client := newTestClient(t, qbittorrent.WithRetryPolicy(fastRetryPolicy()))
+
+	srv.FailNext(torrentsEndpoint, 1, http.StatusInternalServerError, "Internal Server Error")
+	start := srv.Requests(torrentsEndpoint)
+
+	if _, err := client.GetTorrentList(nil); err == nil {
+		t.Fatal("500 was retried")
+	}
+	if got := srv.Requests(torrentsEndpoint) - start; got != 1 {
+		t.Fatalf("got %d attempts, want 1", got)
+	}
+}
+
+func TestRetryDisabled(t *testing.T) {
+	srv, client := newTestClient(t, qbittorrent.WithRetryPolicy(nil))
+
+	srv.FailNext(torrentsEndpoint, 1, http.StatusBadGateway, "Bad Gateway")
+	start := srv.Requests(torrentsEndpoint)
+
+	if _, err := client.GetTorrentList(nil); err == nil {
+		t.Fatal("request was retried")
+	}
+	if got := srv.Requests(torrentsEndpoint) - start; got != 1 {
+		t.Fatalf("got %d attempts, want 1", got)
+	}
+}
+
+func TestRetryConnectionError(t *testing.T) {
+	srv := qbittest.NewServer()
+	srv.Close()
+
+	var mu sync.Mutex
+	attempts := 0
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	client := qbittorrent.NewClient(srv.URL, qbittorrent.WithAuthBypass(), qbittorrent.WithTransport(transport), qbittorrent.WithRetryPolicy(fastRetryPolicy()))
+	if _, err := client.GetAPIVersion(); err == nil {
+		t.Fatal("request to a closed server succeeded")
+	}
+	if attempts != 3 {
+		t.Fatalf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestRetryPOST(t *testing.T) {
+	torrent := func() *qbittorrent.NewTorrentOptions {
+		return qbittorrent.NewTorrent().AddUrl("magnet:?xt=urn:btih:8c212779b4abde7c6bc608063a0d008b7e40ce32")
+	}
+
+	srv, client := newTestClient(t, qbittorrent.WithRetryPolicy(fastRetryPolicy()))
+	srv.FailNext(addEndpoint, 1, http.StatusBadGateway, "Bad Gateway")
+
+	if err := client.AddNewTorrentWithOptions(torrent()); err == nil {
+		t.Fatal("POST was retried without RetryPOST")
+	}
+	if got := srv.Requests(addEndpoint); got != 1 {
+		t.Fatalf("got %d attempts, want 1", got)
+	}
+
+	policy := fastRetryPolicy()
+	policy.RetryPOST = true
+	srv, client = newTestClient(t, qbittorrent.WithRetryPolicy(policy))
+	srv.FailNext(addEndpoint, 1, http.StatusBadGateway, "Bad Gateway")
+
+	if err := client.AddNewTorrentWithOptions(torrent()); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Requests(addEndpoint); got != 2 {
+		t.Fatalf("got %d attempts, want 2", got)
+	}
+}
+
+func TestRetryMultipart(t *testing.T) {
+	policy := fastRetryPolicy()
+	policy.RetryPOST = true
+	srv, client := newTestClient(t, qbittorrent.WithRetryPolicy(policy))
+
+	// the first attempt is read entirely then rejected, so the retry has to send the body again
+	var mu sync.Mutex
+	var bodies [][]byte
+	srv.AddHook(func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path != addEndpoint {
+			return false
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, body)
+		if len(bodies) == 1 {
+			http.Error(w, "Bad Gateway", http.StatusBadGateway)
+			return true
+		}
+		return false
+	})
+
+	path := filepath.Join(t.TempDir(), "debian.torrent")
+	err := os.WriteFile(path, testTorrent(t, "debian", 100<<10), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.AddNewTorrentWithOptions(qbittorrent.NewTorrent().
+		AddFromFile(path).
+		AddFromBytes("ubuntu.torrent", testTorrent(t, "ubuntu", 50<<10)).
+		Category("Linux"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("got %d attempts, want 2", len(bodies))
+	}
+	if !bytes.Equal(bodies[0], bodies[1]) {
+		t.Fatal("the retry sent another multipart body")
+	}
+	if torrents := srv.Torrents(); len(torrents) != 2 {
+		t.Fatalf("got %d torrents, want 2", len(torrents))
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &qbittorrent.RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       50 * time.Millisecond,
+		Multiplier:           2,
+		RetryableStatusCodes: []int{http.StatusBadGateway},
+	}
+	srv, client := newTestClient(t, qbittorrent.WithRetryPolicy(policy))
+	srv.FailNext(torrentsEndpoint, 2, http.StatusBadGateway, "Bad Gateway")
+
+	start := time.Now()
+	if _, err := client.GetTorrentList(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// 50ms then 100ms without jitter
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("the attempts were sent after %v, want at least 150ms", elapsed)
+	}
+}
@@ -1,6 +1,7 @@
 package qbittorrent
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"strconv"
@@ -17,9 +18,14 @@ import (
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#add-folder
 */
 func (c *Client) AddRSSFolder(path string) (err error) {
+	return c.AddRSSFolderCtx(context.Background(), path)
+}
+
+// AddRSSFolderCtx is like [Client.AddRSSFolder] but uses ctx for the underlying requests.
+func (c *Client) AddRSSFolderCtx(ctx context.Context, path string) (err error) {
 	params := url.Values{}
 	params.Add("path", path)
-	_, err = c.postReq("/api/v2/rss/addFolder", &params)
+	_, err = c.postReq(ctx, "/api/v2/rss/addFolder", &params)
 	return
 }
 
@@ -35,10 +41,15 @@ func (c *Client) AddRSSFolder(path string) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#add-feed
 */
 func (c *Client) AddRSSFeed(feedUrl, path string) (err error) {
+	return c.AddRSSFeedCtx(context.Background(), feedUrl, path)
+}
+
+// AddRSSFeedCtx is like [Client.AddRSSFeed] but uses ctx for the underlying requests.
+func (c *Client) AddRSSFeedCtx(ctx context.Context, feedUrl, path string) (err error) {
 	params := url.Values{}
 	params.Add("url", feedUrl)
 	params.Add("path", path)
-	_, err = c.postReq("/api/v2/rss/addFeed", &params)
+	_, err = c.postReq(ctx, "/api/v2/rss/addFeed", &params)
 	return
 }
 
@@ -53,9 +64,14 @@ func (c *Client) AddRSSFeed(feedUrl, path string) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#remove-item
 */
 func (c *Client) RemoveRSSItem(path string) (err error) {
+	return c.RemoveRSSItemCtx(context.Background(), path)
+}
+
+// RemoveRSSItemCtx is like [Client.RemoveRSSItem] but uses ctx for the underlying requests.
+func (c *Client) RemoveRSSItemCtx(ctx context.Context, path string) (err error) {
 	params := url.Values{}
 	params.Add("path", path)
-	_, err = c.postReq("/api/v2/rss/removeItem", &params)
+	_, err = c.postReq(ctx, "/api/v2/rss/removeItem", &params)
 	return
 }
 
@@ -71,10 +87,15 @@ func (c *Client) RemoveRSSItem(path string) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#move-item
 */
 func (c *Client) MoveRSSItem(itemPath, destPath string) (err error) {
+	return c.MoveRSSItemCtx(context.Background(), itemPath, destPath)
+}
+
+// MoveRSSItemCtx is like [Client.MoveRSSItem] but uses ctx for the underlying requests.
+func (c *Client) MoveRSSItemCtx(ctx context.Context, itemPath, destPath string) (err error) {
 	params := url.Values{}
 	params.Add("itemPath", itemPath)
 	params.Add("destPath", destPath)
-	_, err = c.postReq("/api/v2/rss/moveItem", &params)
+	_, err = c.postReq(ctx, "/api/v2/rss/moveItem", &params)
 	return
 }
 
@@ -88,10 +109,15 @@ func (c *Client) MoveRSSItem(itemPath, destPath string) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-all-items
 */
 func (c *Client) GetAllRSSItems(withData bool) (results map[string]interface{}, err error) {
+	return c.GetAllRSSItemsCtx(context.Background(), withData)
+}
+
+// GetAllRSSItemsCtx is like [Client.GetAllRSSItems] but uses ctx for the underlying requests.
+func (c *Client) GetAllRSSItemsCtx(ctx context.Context, withData bool) (results map[string]interface{}, err error) {
 	form := url.Values{}
 	form.Add("withData", strconv.FormatBool(withData))
 
-	body, err := c.getReq("/api/v2/rss/items", &form)
+	body, err := c.getReq(ctx, "/api/v2/rss/items", &form)
 	if err != nil {
 		return nil, err
 	}
@@ -112,10 +138,15 @@ func (c *Client) GetAllRSSItems(withData bool) (results map[string]interface{},
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#mark-as-read
 */
 func (c *Client) MarkRSSAsRead(itemPath, articleId string) (err error) {
+	return c.MarkRSSAsReadCtx(context.Background(), itemPath, articleId)
+}
+
+// MarkRSSAsReadCtx is like [Client.MarkRSSAsRead] but uses ctx for the underlying requests.
+func (c *Client) MarkRSSAsReadCtx(ctx context.Context, itemPath, articleId string) (err error) {
 	params := url.Values{}
 	params.Add("itemPath", itemPath)
 	params.Add("articleId", articleId)
-	_, err = c.postReq("/api/v2/rss/markAsRead", &params)
+	_, err = c.postReq(ctx, "/api/v2/rss/markAsRead", &params)
 	return
 }
 
@@ -129,9 +160,14 @@ func (c *Client) MarkRSSAsRead(itemPath, articleId string) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#refresh-item
 */
 func (c *Client) RefreshRSSItem(itemPath string) (err error) {
+	return c.RefreshRSSItemCtx(context.Background(), itemPath)
+}
+
+// RefreshRSSItemCtx is like [Client.RefreshRSSItem] but uses ctx for the underlying requests.
+func (c *Client) RefreshRSSItemCtx(ctx context.Context, itemPath string) (err error) {
 	params := url.Values{}
 	params.Add("itemPath", itemPath)
-	_, err = c.postReq("/api/v2/rss/refreshItem", &params)
+	_, err = c.postReq(ctx, "/api/v2/rss/refreshItem", &params)
 	return
 }
 
@@ -146,6 +182,11 @@ func (c *Client) RefreshRSSItem(itemPath string) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-auto-downloading-rule
 */
 func (c *Client) SetRSSAutoDownloadingRule(ruleName string, ruleDef map[string]interface{}) (err error) {
+	return c.SetRSSAutoDownloadingRuleCtx(context.Background(), ruleName, ruleDef)
+}
+
+// SetRSSAutoDownloadingRuleCtx is like [Client.SetRSSAutoDownloadingRule] but uses ctx for the underlying requests.
+func (c *Client) SetRSSAutoDownloadingRuleCtx(ctx context.Context, ruleName string, ruleDef map[string]interface{}) (err error) {
 	ruleDefStr, err := json.Marshal(ruleDef)
 	if err != nil {
 		return
@@ -155,7 +196,7 @@ func (c *Client) SetRSSAutoDownloadingRule(ruleName string, ruleDef map[string]i
 	params.Add("ruleName", ruleName)
 	params.Add("ruleDef", string(ruleDefStr))
 
-	_, err = c.postReq("/api/v2/rss/setRule", &params)
+	_, err = c.postReq(ctx, "/api/v2/rss/setRule", &params)
 
 	return
 }
@@ -171,10 +212,15 @@ func (c *Client) SetRSSAutoDownloadingRule(ruleName string, ruleDef map[string]i
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#rename-auto-downloading-rule
 */
 func (c *Client) RenameRSSAutoDownloadingRule(ruleName, newRuleName string) (err error) {
+	return c.RenameRSSAutoDownloadingRuleCtx(context.Background(), ruleName, newRuleName)
+}
+
+// RenameRSSAutoDownloadingRuleCtx is like [Client.RenameRSSAutoDownloadingRule] but uses ctx for the underlying requests.
+func (c *Client) RenameRSSAutoDownloadingRuleCtx(ctx context.Context, ruleName, newRuleName string) (err error) {
 	params := url.Values{}
 	params.Add("ruleName", ruleName)
 	params.Add("newRuleName", newRuleName)
-	_, err = c.postReq("/api/v2/rss/renameRule", &params)
+	_, err = c.postReq(ctx, "/api/v2/rss/renameRule", &params)
 	return
 }
 
@@ -188,9 +234,14 @@ func (c *Client) RenameRSSAutoDownloadingRule(ruleName, newRuleName string) (err
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#remove-auto-downloading-rule
 */
 func (c *Client) RemoveRSSAutoDownloadingRule(ruleName string) (err error) {
+	return c.RemoveRSSAutoDownloadingRuleCtx(context.Background(), ruleName)
+}
+
+// RemoveRSSAutoDownloadingRuleCtx is like [Client.RemoveRSSAutoDownloadingRule] but uses ctx for the underlying requests.
+func (c *Client) RemoveRSSAutoDownloadingRuleCtx(ctx context.Context, ruleName string) (err error) {
 	params := url.Values{}
 	params.Add("ruleName", ruleName)
-	_, err = c.postReq("/api/v2/rss/removeRule", &params)
+	_, err = c.postReq(ctx, "/api/v2/rss/removeRule", &params)
 	return
 }
 
@@ -203,7 +254,12 @@ Returns all auto-downloading rules
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-all-auto-downloading-rules
 */
 func (c *Client) GetAllRSSDownloadingRules() (results map[string]RSSDownloadingRule, err error) {
-	body, err := c.getReq("/api/v2/rss/rules", nil)
+	return c.GetAllRSSDownloadingRulesCtx(context.Background())
+}
+
+// GetAllRSSDownloadingRulesCtx is like [Client.GetAllRSSDownloadingRules] but uses ctx for the underlying requests.
+func (c *Client) GetAllRSSDownloadingRulesCtx(ctx context.Context) (results map[string]RSSDownloadingRule, err error) {
+	body, err := c.getReq(ctx, "/api/v2/rss/rules", nil)
 	if err != nil {
 		return
 	}
@@ -223,10 +279,15 @@ Returns all articles that match a rule by feed name
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-all-articles-matching-a-rule
 */
 func (c *Client) GetAllRSSArticlesMatchingRule(ruleName string) (results map[string][]string, err error) {
+	return c.GetAllRSSArticlesMatchingRuleCtx(context.Background(), ruleName)
+}
+
+// GetAllRSSArticlesMatchingRuleCtx is like [Client.GetAllRSSArticlesMatchingRule] but uses ctx for the underlying requests.
+func (c *Client) GetAllRSSArticlesMatchingRuleCtx(ctx context.Context, ruleName string) (results map[string][]string, err error) {
 	form := url.Values{}
 	form.Add("ruleName", ruleName)
 
-	body, err := c.getReq("/api/v2/rss/matchingArticles", &form)
+	body, err := c.getReq(ctx, "/api/v2/rss/matchingArticles", &form)
 	if err != nil {
 		return
 	}
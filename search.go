@@ -1,10 +1,13 @@
 package qbittorrent
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 /*
@@ -23,12 +26,17 @@ import (
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#start-search
 */
 func (c *Client) StartSearch(pattern string, plugins []string, category []string) (results int, err error) {
+	return c.StartSearchCtx(context.Background(), pattern, plugins, category)
+}
+
+// StartSearchCtx is like [Client.StartSearch] but uses ctx for the underlying requests.
+func (c *Client) StartSearchCtx(ctx context.Context, pattern string, plugins []string, category []string) (results int, err error) {
 	params := url.Values{}
 	params.Add("pattern", pattern)
 	params.Add("plugins", strings.Join(plugins, "|"))
 	params.Add("category", strings.Join(category, ","))
 
-	body, err := c.postReq("/api/v2/search/start", &params)
+	body, err := c.postReq(ctx, "/api/v2/search/start", &params)
 	if err != nil {
 		return
 	}
@@ -55,9 +63,14 @@ func (c *Client) StartSearch(pattern string, plugins []string, category []string
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#stop-search
 */
 func (c *Client) StopSearch(id int) (err error) {
+	return c.StopSearchCtx(context.Background(), id)
+}
+
+// StopSearchCtx is like [Client.StopSearch] but uses ctx for the underlying requests.
+func (c *Client) StopSearchCtx(ctx context.Context, id int) (err error) {
 	params := url.Values{}
 	params.Add("id", strconv.Itoa(id))
-	_, err = c.postReq("/api/v2/search/stop", &params)
+	_, err = c.postReq(ctx, "/api/v2/search/stop", &params)
 	return
 }
 
@@ -72,12 +85,17 @@ func (c *Client) StopSearch(id int) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-search-status
 */
 func (c *Client) GetSearchStatus(id *int) (results []SearchStatusResponse, err error) {
+	return c.GetSearchStatusCtx(context.Background(), id)
+}
+
+// GetSearchStatusCtx is like [Client.GetSearchStatus] but uses ctx for the underlying requests.
+func (c *Client) GetSearchStatusCtx(ctx context.Context, id *int) (results []SearchStatusResponse, err error) {
 	params := url.Values{}
 	if id != nil {
 		params.Add("id", strconv.Itoa(*id))
 	}
 
-	body, err := c.getReq("/api/v2/search/status", &params)
+	body, err := c.getReq(ctx, "/api/v2/search/status", &params)
 	if err != nil {
 		return
 	}
@@ -100,6 +118,11 @@ func (c *Client) GetSearchStatus(id *int) (results []SearchStatusResponse, err e
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-search-results
 */
 func (c *Client) GetSearchResults(id int, limit, offset *int) (results SearchResultsResponse, err error) {
+	return c.GetSearchResultsCtx(context.Background(), id, limit, offset)
+}
+
+// GetSearchResultsCtx is like [Client.GetSearchResults] but uses ctx for the underlying requests.
+func (c *Client) GetSearchResultsCtx(ctx context.Context, id int, limit, offset *int) (results SearchResultsResponse, err error) {
 	params := url.Values{}
 	params.Add("id", strconv.Itoa(id))
 	if limit != nil {
@@ -109,7 +132,7 @@ func (c *Client) GetSearchResults(id int, limit, offset *int) (results SearchRes
 		params.Add("offset", strconv.Itoa(*offset))
 	}
 
-	body, err := c.getReq("/api/v2/search/results", &params)
+	body, err := c.getReq(ctx, "/api/v2/search/results", &params)
 	if err != nil {
 		return
 	}
@@ -129,9 +152,14 @@ func (c *Client) GetSearchResults(id int, limit, offset *int) (results SearchRes
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#delete-search
 */
 func (c *Client) DeleteSearch(id int) (err error) {
+	return c.DeleteSearchCtx(context.Background(), id)
+}
+
+// DeleteSearchCtx is like [Client.DeleteSearch] but uses ctx for the underlying requests.
+func (c *Client) DeleteSearchCtx(ctx context.Context, id int) (err error) {
 	params := url.Values{}
 	params.Add("id", strconv.Itoa(id))
-	_, err = c.postReq("/api/v2/search/delete", &params)
+	_, err = c.postReq(ctx, "/api/v2/search/delete", &params)
 	return
 }
 
@@ -142,7 +170,12 @@ func (c *Client) DeleteSearch(id int) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-search-plugins
 */
 func (c *Client) GetSearchPlugins() (results []SearchPluginsResponse, err error) {
-	body, err := c.getReq("/api/v2/search/plugins", nil)
+	return c.GetSearchPluginsCtx(context.Background())
+}
+
+// GetSearchPluginsCtx is like [Client.GetSearchPlugins] but uses ctx for the underlying requests.
+func (c *Client) GetSearchPluginsCtx(ctx context.Context) (results []SearchPluginsResponse, err error) {
+	body, err := c.getReq(ctx, "/api/v2/search/plugins", nil)
 	if err != nil {
 		return
 	}
@@ -161,9 +194,14 @@ func (c *Client) GetSearchPlugins() (results []SearchPluginsResponse, err error)
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#install-search-plugin
 */
 func (c *Client) InstallSearchPlugin(sources []string) (err error) {
+	return c.InstallSearchPluginCtx(context.Background(), sources)
+}
+
+// InstallSearchPluginCtx is like [Client.InstallSearchPlugin] but uses ctx for the underlying requests.
+func (c *Client) InstallSearchPluginCtx(ctx context.Context, sources []string) (err error) {
 	params := url.Values{}
 	params.Add("sources", strings.Join(sources, "|"))
-	_, err = c.postReq("/api/v2/search/installPlugin", &params)
+	_, err = c.postReq(ctx, "/api/v2/search/installPlugin", &params)
 	return
 }
 
@@ -177,9 +215,14 @@ func (c *Client) InstallSearchPlugin(sources []string) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#uninstall-search-plugin
 */
 func (c *Client) UninstallSearchPlugin(names []string) (err error) {
+	return c.UninstallSearchPluginCtx(context.Background(), names)
+}
+
+// UninstallSearchPluginCtx is like [Client.UninstallSearchPlugin] but uses ctx for the underlying requests.
+func (c *Client) UninstallSearchPluginCtx(ctx context.Context, names []string) (err error) {
 	params := url.Values{}
 	params.Add("names", strings.Join(names, "|"))
-	_, err = c.postReq("/api/v2/search/uninstallPlugin", &params)
+	_, err = c.postReq(ctx, "/api/v2/search/uninstallPlugin", &params)
 	return
 }
 
@@ -194,10 +237,15 @@ func (c *Client) UninstallSearchPlugin(names []string) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#enable-search-plugin
 */
 func (c *Client) EnableSearchPlugin(names []string, enable bool) (err error) {
+	return c.EnableSearchPluginCtx(context.Background(), names, enable)
+}
+
+// EnableSearchPluginCtx is like [Client.EnableSearchPlugin] but uses ctx for the underlying requests.
+func (c *Client) EnableSearchPluginCtx(ctx context.Context, names []string, enable bool) (err error) {
 	params := url.Values{}
 	params.Add("names", strings.Join(names, "|"))
 	params.Add("enable", strconv.FormatBool(enable))
-	_, err = c.postReq("/api/v2/search/enablePlugin", &params)
+	_, err = c.postReq(ctx, "/api/v2/search/enablePlugin", &params)
 	return
 }
 
@@ -208,6 +256,160 @@ func (c *Client) EnableSearchPlugin(names []string, enable bool) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#update-search-plugins
 */
 func (c *Client) UpdateSearchPlugins() (err error) {
-	_, err = c.postReq("/api/v2/search/updatePlugins", nil)
+	return c.UpdateSearchPluginsCtx(context.Background())
+}
+
+// UpdateSearchPluginsCtx is like [Client.UpdateSearchPlugins] but uses ctx for the underlying requests.
+func (c *Client) UpdateSearchPluginsCtx(ctx context.Context) (err error) {
+	_, err = c.postReq(ctx, "/api/v2/search/updatePlugins", nil)
 	return
 }
+
+// qBittorrent refuses to run more than 5 searches at the same time
+const maxRunningSearches = 5
+
+type SearchOptions struct {
+	Plugins    []string      // Plugins to use for searching. Defaults to `enabled`
+	Categories []string      // Categories to limit your search to. Defaults to `all`
+	Timeout    time.Duration // Stop the search after this duration. 0 means no timeout
+	MaxResults int           // Stop the search after this many results. 0 means no limit
+	Interval   time.Duration // Interval between polls of the search results. Defaults to 1.5 seconds
+}
+
+/*
+Search runs a whole search job: it starts the search, streams new results as the server finds them and
+deletes the job once the search is over, the timeout or the max number of results is reached, or ctx is done.
+
+No more than 5 searches run at the same time per client, additional calls wait for a running search to end.
+The same happens when the server answers 409 because of searches started elsewhere.
+
+Both channels are closed when the search is over. If the search fails the error is sent on the error
+channel first. Cancelling ctx or reaching the timeout is not reported as an error.
+
+# Example
+
+	results, errs := client.Search(ctx, "ubuntu", &qbittorrent.SearchOptions{MaxResults: 50})
+	for result := range results {
+	 fmt.Println(result.FileName, result.NbSeeders)
+	}
+	if err := <-errs; err != nil {
+	 panic(err)
+	}
+*/
+func (c *Client) Search(ctx context.Context, pattern string, opts *SearchOptions) (<-chan SearchResult, <-chan error) {
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+
+	plugins := opts.Plugins
+	if len(plugins) == 0 {
+		plugins = []string{"enabled"}
+	}
+	categories := opts.Categories
+	if len(categories) == 0 {
+		categories = []string{"all"}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	results := make(chan SearchResult)
+	errs := make(chan error, 1)
+
+	wait := func(ctx context.Context) bool {
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			return true
+		}
+	}
+
+	go func() {
+		defer close(errs)
+		defer close(results)
+
+		report := func(err error) {
+			if ctx.Err() == nil {
+				errs <- err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case c.searchSlots <- struct{}{}:
+		}
+		defer func() { <-c.searchSlots }()
+
+		var id int
+		for {
+			var err error
+			id, err = c.StartSearchCtx(ctx, pattern, plugins, categories)
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, ErrConflict) {
+				report(err)
+				return
+			}
+			// the server is running too many searches, wait for one to end
+			if !wait(ctx) {
+				return
+			}
+		}
+
+		defer func() {
+			// the job must be deleted even if ctx is done
+			cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+			defer cancel()
+			_ = c.DeleteSearchCtx(cleanupCtx, id)
+		}()
+
+		runCtx := ctx
+		if opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			runCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+		}
+
+		offset, sent := 0, 0
+		for {
+			response, err := c.GetSearchResultsCtx(runCtx, id, nil, &offset)
+			if err != nil {
+				if runCtx.Err() == nil {
+					report(err)
+				}
+				return
+			}
+			offset += len(response.Results)
+
+			for _, result := range response.Results {
+				select {
+				case <-runCtx.Done():
+					return
+				case results <- result:
+				}
+
+				sent++
+				if opts.MaxResults > 0 && sent >= opts.MaxResults {
+					return
+				}
+			}
+
+			if response.Status == SearchStatusStopped && offset >= response.Total {
+				return
+			}
+
+			if !wait(runCtx) {
+				return
+			}
+		}
+	}()
+
+	return results, errs
+}
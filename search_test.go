@@ -0,0 +1,167 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+const (
+	searchStartEndpoint  = "/api/v2/search/start"
+	searchDeleteEndpoint = "/api/v2/search/delete"
+)
+
+// newSearchServer returns a client of a server finding 4 results, 3 of them for "ubuntu"
+func newSearchServer(t *testing.T, duration time.Duration) (*qbittest.Server, *qbittorrent.Client) {
+	t.Helper()
+
+	srv, client := newTestClient(t)
+	srv.SetSearchResults([]qbittorrent.SearchResult{
+		{FileName: "ubuntu-24.04-desktop-amd64.iso", NbSeeders: 100},
+		{FileName: "debian-12.5.0-amd64-netinst.iso", NbSeeders: 50},
+		{FileName: "ubuntu-24.04-live-server-amd64.iso", NbSeeders: 80},
+		{FileName: "ubuntu-22.04-desktop-amd64.iso", NbSeeders: 20},
+	})
+	srv.SetSearchDuration(duration)
+
+	return srv, client
+}
+
+// collectSearch reads the results of a search until its channels are closed
+func collectSearch(t *testing.T, results <-chan qbittorrent.SearchResult, errs <-chan error) (names []string, err error) {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				return names, <-errs
+			}
+			names = append(names, result.FileName)
+		case <-timeout:
+			t.Fatal("the search didn't end")
+		}
+	}
+}
+
+// checkSearchDeleted checks that the search job was deleted once
+func checkSearchDeleted(t *testing.T, srv *qbittest.Server, client *qbittorrent.Client) {
+	t.Helper()
+
+	if got := srv.Requests(searchDeleteEndpoint); got != 1 {
+		t.Fatalf("got %d deleted searches, want 1", got)
+	}
+	jobs, err := client.GetSearchStatus(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 0 {
+		t.Fatalf("got search jobs %+v after the search", jobs)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	srv, client := newSearchServer(t, 100*time.Millisecond)
+
+	results, errs := client.Search(context.Background(), "Ubuntu amd64", &qbittorrent.SearchOptions{Interval: 10 * time.Millisecond})
+	names, err := collectSearch(t, results, errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"ubuntu-24.04-desktop-amd64.iso", "ubuntu-24.04-live-server-amd64.iso", "ubuntu-22.04-desktop-amd64.iso"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("got results %v, want %v", names, want)
+	}
+	// the results are streamed while the search runs
+	if got := srv.Requests("/api/v2/search/results"); got < 3 {
+		t.Fatalf("got %d polls of the results, want several", got)
+	}
+	checkSearchDeleted(t, srv, client)
+}
+
+func TestSearchMaxResults(t *testing.T) {
+	srv, client := newSearchServer(t, 0)
+
+	results, errs := client.Search(context.Background(), "ubuntu", &qbittorrent.SearchOptions{MaxResults: 2, Interval: 10 * time.Millisecond})
+	names, err := collectSearch(t, results, errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("got results %v, want 2", names)
+	}
+	checkSearchDeleted(t, srv, client)
+}
+
+func TestSearchCancel(t *testing.T) {
+	srv, client := newSearchServer(t, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results, errs := client.Search(ctx, "ubuntu", &qbittorrent.SearchOptions{Interval: 10 * time.Millisecond})
+	waitRequests(t, srv, "/api/v2/search/results", 2)
+	cancel()
+
+	// the job is deleted before the channels are closed, even though ctx is done
+	if _, err := collectSearch(t, results, errs); err != nil {
+		t.Fatalf("got error %v after cancelling", err)
+	}
+	checkSearchDeleted(t, srv, client)
+}
+
+func TestSearchTimeout(t *testing.T) {
+	srv, client := newSearchServer(t, time.Hour)
+
+	start := time.Now()
+	results, errs := client.Search(context.Background(), "ubuntu", &qbittorrent.SearchOptions{Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond})
+	if _, err := collectSearch(t, results, errs); err != nil {
+		t.Fatalf("got error %v after the timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("the search ended after %v, before the timeout", elapsed)
+	}
+	checkSearchDeleted(t, srv, client)
+}
+
+func TestSearchTooManySearches(t *testing.T) {
+	srv, client := newSearchServer(t, 0)
+
+	// searches started elsewhere make the server answer 409 until one of them ends
+	srv.FailNext(searchStartEndpoint, 2, http.StatusConflict, "Unable to create more than 5 concurrent searches.")
+
+	results, errs := client.Search(context.Background(), "debian", &qbittorrent.SearchOptions{Interval: 10 * time.Millisecond})
+	names, err := collectSearch(t, results, errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"debian-12.5.0-amd64-netinst.iso"}) {
+		t.Fatalf("got results %v", names)
+	}
+	if got := srv.Requests(searchStartEndpoint); got != 3 {
+		t.Fatalf("got %d start requests, want 3", got)
+	}
+	checkSearchDeleted(t, srv, client)
+}
+
+func TestSearchError(t *testing.T) {
+	srv, client := newSearchServer(t, 0)
+	srv.FailNext(searchStartEndpoint, 1, http.StatusBadRequest, "Bad Request")
+
+	results, errs := client.Search(context.Background(), "ubuntu", &qbittorrent.SearchOptions{Interval: 10 * time.Millisecond})
+	if _, err := collectSearch(t, results, errs); !errors.Is(err, qbittorrent.ErrBadRequest) {
+		t.Fatalf("got error %v, want ErrBadRequest", err)
+	}
+	// no job was started, there is nothing to delete
+	if got := srv.Requests(searchDeleteEndpoint); got != 0 {
+		t.Fatalf("got %d deleted searches, want 0", got)
+	}
+}
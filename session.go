@@ -0,0 +1,240 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Session is the WebUI session of a [Client], it can be exported and imported to skip logging in again.
+type Session struct {
+	ServerURL  string    `json:"server_url"`        // URL of the server the session belongs to
+	CookieName string    `json:"cookie_name"`       // Name of the session cookie, "SID" or "QBT_SID_<port>" since qBittorrent 5.0
+	SID        string    `json:"sid"`               // Value of the session cookie
+	Expires    time.Time `json:"expires,omitempty"` // Expiry of the session cookie, zero when the server didn't set one
+}
+
+// Expired reports whether the session cookie has expired.
+func (s *Session) Expired() bool {
+	return !s.Expires.IsZero() && time.Now().After(s.Expires)
+}
+
+/*
+SessionStore persists the session of a [Client], use it with [WithSessionStore].
+
+Load returns a nil session when nothing is stored.
+Implementations must be safe for concurrent use, as a client may save a new session while another goroutine logs out.
+*/
+type SessionStore interface {
+	Load(ctx context.Context) (session *Session, err error)
+	Save(ctx context.Context, session *Session) (err error)
+	Delete(ctx context.Context) (err error)
+}
+
+/*
+ExportSession returns the current session of the client, the client must be logged in.
+
+# Example
+
+	session, err := client.ExportSession()
+	if err != nil {
+	 panic(err)
+	}
+
+	// later, in another process
+	err = client.ImportSession(session)
+*/
+func (c *Client) ExportSession() (session *Session, err error) {
+	u, err := url.Parse(c.ServerURL)
+	if err != nil {
+		return
+	}
+
+	for _, cookie := range c.Jar.Cookies(u) {
+		if isSessionCookie(cookie.Name) {
+			c.mu.Lock()
+			expires := c.sessionExpires
+			c.mu.Unlock()
+
+			return &Session{ServerURL: c.ServerURL, CookieName: cookie.Name, SID: cookie.Value, Expires: expires}, nil
+		}
+	}
+
+	return nil, ErrNoSession
+}
+
+/*
+ImportSession uses a session exported by [Client.ExportSession] for the next requests.
+
+The session is not validated, a rejected session makes the requests fail with [ErrForbidden].
+Use [WithSessionStore] to validate a stored session and log in again when it's rejected.
+*/
+func (c *Client) ImportSession(session *Session) (err error) {
+	if session == nil || session.SID == "" {
+		return ErrNoSession
+	}
+
+	if strings.TrimSuffix(session.ServerURL, "/") != strings.TrimSuffix(c.ServerURL, "/") {
+		return errors.New("qbittorrent: the session belongs to another server: " + session.ServerURL)
+	}
+
+	u, err := url.Parse(c.ServerURL)
+	if err != nil {
+		return
+	}
+
+	name := session.CookieName
+	if name == "" {
+		name = "SID"
+	}
+
+	c.Jar.SetCookies(u, []*http.Cookie{{Name: name, Value: session.SID, Path: "/", Expires: session.Expires}})
+
+	c.mu.Lock()
+	c.sessionExpires = session.Expires
+	c.mu.Unlock()
+
+	return
+}
+
+// restoreSession imports the stored session and checks that the server accepts it
+func (c *Client) restoreSession(ctx context.Context) (restored bool, err error) {
+	session, err := c.sessions.Load(ctx)
+	if err != nil {
+		// e.g. a corrupt file, it's replaced by the session of the login
+		c.sessionError(fmt.Errorf("qbittorrent: loading the stored session: %w", err))
+		if err := c.sessions.Delete(ctx); err != nil {
+			c.sessionError(fmt.Errorf("qbittorrent: deleting the stored session: %w", err))
+		}
+		return false, nil
+	}
+	if session == nil || session.Expired() {
+		return
+	}
+
+	err = c.ImportSession(session)
+	if err != nil {
+		// the store holds the session of another server, log in instead
+		return false, nil
+	}
+
+	// sent without the re-login of c.do, a rejected session is not an error here
+	reqURL, err := c.endpointURL("/api/v2/app/webapiVersion", nil)
+	if err != nil {
+		return
+	}
+
+	_, err = c.sendWithRetry(ctx, "GET", reqURL, "/api/v2/app/webapiVersion", nil)
+	if errors.Is(err, ErrForbidden) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// sessionError reports a session store error that doesn't fail the login
+func (c *Client) sessionError(err error) {
+	if c.sessionErrors != nil {
+		c.sessionErrors(err)
+	}
+}
+
+// saveSession saves the current session in the session store
+func (c *Client) saveSession(ctx context.Context) (err error) {
+	session, err := c.ExportSession()
+	if err != nil {
+		return
+	}
+
+	return c.sessions.Save(ctx, session)
+}
+
+// isSessionCookie reports whether name is the name of the qBittorrent session cookie
+func isSessionCookie(name string) bool {
+	return name == "SID" || strings.HasPrefix(name, "QBT_SID_")
+}
+
+func cookieExpires(cookie *http.Cookie) time.Time {
+	if cookie.MaxAge > 0 {
+		return time.Now().Add(time.Duration(cookie.MaxAge) * time.Second)
+	}
+
+	return cookie.Expires
+}
+
+// FileSessionStore is a [SessionStore] that keeps the session in a JSON file only readable by the current user.
+type FileSessionStore struct {
+	path string
+}
+
+// NewFileSessionStore returns a [FileSessionStore] using the file at path, the file and its directory are created when needed.
+func NewFileSessionStore(path string) *FileSessionStore {
+	return &FileSessionStore{path: path}
+}
+
+func (s *FileSessionStore) Load(_ context.Context) (session *Session, err error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+
+	session = &Session{}
+	err = json.Unmarshal(data, session)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Save writes the session to a temporary file and renames it, so a concurrent Load never reads a partial file.
+func (s *FileSessionStore) Save(_ context.Context, session *Session) (err error) {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return
+	}
+
+	dir := filepath.Dir(s.path)
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return
+	}
+
+	file, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+		return
+	}
+
+	err = file.Close()
+	if err != nil {
+		return
+	}
+
+	return os.Rename(file.Name(), s.path)
+}
+
+func (s *FileSessionStore) Delete(_ context.Context) (err error) {
+	err = os.Remove(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return
+}
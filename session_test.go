@@ -0,0 +1,217 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+// errorStore is a SessionStore failing with err
+type errorStore struct {
+	err error
+}
+
+func (s *errorStore) Load(context.Context) (*qbittorrent.Session, error) { return nil, s.err }
+func (s *errorStore) Save(context.Context, *qbittorrent.Session) error   { return s.err }
+func (s *errorStore) Delete(context.Context) error                       { return s.err }
+
+// emptyStore is a SessionStore holding no session, failing to save it with err
+type emptyStore struct {
+	errorStore
+}
+
+func (s *emptyStore) Load(context.Context) (*qbittorrent.Session, error) { return nil, nil }
+
+func login(t *testing.T, client *qbittorrent.Client) {
+	t.Helper()
+
+	err := client.Login(qbittest.DefaultUsername, qbittest.DefaultPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSessionStoreRestore(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	store := qbittorrent.NewFileSessionStore(filepath.Join(t.TempDir(), "qbittorrent", "session.json"))
+
+	login(t, qbittorrent.NewClient(srv.URL, qbittorrent.WithSessionStore(store)))
+	if got := srv.Requests(loginEndpoint); got != 1 {
+		t.Fatalf("got %d logins, want 1", got)
+	}
+
+	saved, err := store.Load(context.Background())
+	if err != nil || saved == nil || saved.SID == "" {
+		t.Fatalf("got stored session %+v, %v", saved, err)
+	}
+
+	// the stored session is valid, the next client uses it without logging in
+	client := qbittorrent.NewClient(srv.URL, qbittorrent.WithSessionStore(store))
+	login(t, client)
+	if got := srv.Requests(loginEndpoint); got != 1 {
+		t.Fatalf("got %d logins after restoring the session, want 1", got)
+	}
+	if _, err := client.GetTorrentList(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Logout(); err != nil {
+		t.Fatal(err)
+	}
+	if deleted, _ := store.Load(context.Background()); deleted != nil {
+		t.Fatal("Logout did not delete the stored session")
+	}
+}
+
+func TestSessionStoreStale(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	store := qbittorrent.NewFileSessionStore(filepath.Join(t.TempDir(), "session.json"))
+
+	login(t, qbittorrent.NewClient(srv.URL, qbittorrent.WithSessionStore(store)))
+	stale, _ := store.Load(context.Background())
+
+	// the server restarted, the stored session is rejected and a new one is saved
+	srv.ExpireSessions()
+
+	client := qbittorrent.NewClient(srv.URL, qbittorrent.WithSessionStore(store))
+	login(t, client)
+	if got := srv.Requests(loginEndpoint); got != 2 {
+		t.Fatalf("got %d logins, want 2", got)
+	}
+
+	saved, err := store.Load(context.Background())
+	if err != nil || saved == nil || saved.SID == stale.SID {
+		t.Fatalf("the new session was not saved: got %+v, %v", saved, err)
+	}
+	if _, err := client.GetTorrentList(nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSessionStoreExpired(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	store := qbittorrent.NewFileSessionStore(filepath.Join(t.TempDir(), "session.json"))
+
+	login(t, qbittorrent.NewClient(srv.URL, qbittorrent.WithSessionStore(store)))
+	session, _ := store.Load(context.Background())
+	session.Expires = time.Now().Add(-time.Minute)
+	store.Save(context.Background(), session)
+	validations := srv.Requests("/api/v2/app/webapiVersion")
+
+	// an expired session is not sent to the server
+	login(t, qbittorrent.NewClient(srv.URL, qbittorrent.WithSessionStore(store)))
+	if got := srv.Requests("/api/v2/app/webapiVersion") - validations; got != 0 {
+		t.Fatalf("the expired session was validated %d times", got)
+	}
+	if got := srv.Requests(loginEndpoint); got != 2 {
+		t.Fatalf("got %d logins, want 2", got)
+	}
+}
+
+func TestSessionStoreCorrupt(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	err := os.WriteFile(path, []byte(`{"server_url":"`+srv.URL+`","sid":`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := qbittorrent.NewFileSessionStore(path)
+
+	// the truncated file is reported, deleted and replaced by the session of the login
+	var reported []error
+	client := qbittorrent.NewClient(srv.URL, qbittorrent.WithSessionStore(store),
+		qbittorrent.WithSessionErrorHandler(func(err error) { reported = append(reported, err) }))
+	login(t, client)
+
+	if got := srv.Requests(loginEndpoint); got != 1 {
+		t.Fatalf("got %d logins, want 1", got)
+	}
+	var syntaxErr *json.SyntaxError
+	if len(reported) != 1 || !errors.As(reported[0], &syntaxErr) {
+		t.Fatalf("got reported errors %v, want the JSON error", reported)
+	}
+
+	saved, err := store.Load(context.Background())
+	if err != nil || saved == nil || saved.SID == "" {
+		t.Fatalf("got stored session %+v, %v", saved, err)
+	}
+}
+
+func TestSessionStoreError(t *testing.T) {
+	srv := qbittest.NewServer()
+	defer srv.Close()
+
+	errStore := errors.New("store unavailable")
+
+	// every operation of the store fails, the client logs in and works without it
+	var reported []error
+	client := qbittorrent.NewClient(srv.URL, qbittorrent.WithSessionStore(&errorStore{err: errStore}),
+		qbittorrent.WithSessionErrorHandler(func(err error) { reported = append(reported, err) }))
+	login(t, client)
+
+	if got := srv.Requests(loginEndpoint); got != 1 {
+		t.Fatalf("got %d logins after a load error, want 1", got)
+	}
+	// loading, deleting the unreadable session, then saving the new one
+	if len(reported) != 3 {
+		t.Fatalf("got reported errors %v, want 3", reported)
+	}
+	for _, err := range reported {
+		if !errors.Is(err, errStore) {
+			t.Fatalf("got reported error %v, want the store error", err)
+		}
+	}
+	if _, err := client.GetTorrentList(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// a session that can't be saved doesn't fail logging in again either
+	client = qbittorrent.NewClient(srv.URL, qbittorrent.WithSessionStore(&emptyStore{errorStore{err: errStore}}))
+	login(t, client)
+	srv.ExpireSessions()
+	if _, err := client.GetTorrentList(nil); err != nil {
+		t.Fatalf("got error %v after logging in again", err)
+	}
+	if got := srv.Requests(loginEndpoint); got != 3 {
+		t.Fatalf("got %d logins, want 3", got)
+	}
+}
+
+func TestImportSession(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	session, err := client.ExportSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := qbittorrent.NewClient(srv.URL)
+	if err := other.ImportSession(session); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.GetTorrentList(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := qbittorrent.NewClient("http://localhost:1").ImportSession(session); err == nil {
+		t.Fatal("imported the session of another server")
+	}
+	if _, err := qbittorrent.NewClient(srv.URL).ExportSession(); !errors.Is(err, qbittorrent.ErrNoSession) {
+		t.Fatalf("got error %v, want ErrNoSession", err)
+	}
+}
@@ -1,6 +1,7 @@
 package qbittorrent
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"strconv"
@@ -16,11 +17,16 @@ import (
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-main-data
 */
 func (c *Client) GetSyncMainData(rid int) (results SyncMainDataResponse, err error) {
+	return c.GetSyncMainDataCtx(context.Background(), rid)
+}
+
+// GetSyncMainDataCtx is like [Client.GetSyncMainData] but uses ctx for the underlying requests.
+func (c *Client) GetSyncMainDataCtx(ctx context.Context, rid int) (results SyncMainDataResponse, err error) {
 
 	queryParams := url.Values{}
 	queryParams.Add("rid", strconv.Itoa(rid))
 
-	body, err := c.getReq("/api/v2/sync/maindata", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/sync/maindata", &queryParams)
 	if err != nil {
 		return
 	}
@@ -39,17 +45,23 @@ func (c *Client) GetSyncMainData(rid int) (results SyncMainDataResponse, err err
   - "rid" Response ID. If not provided, rid=0 will be assumed. If the given rid is different from the one of last server reply, full_update will be true (see the server reply details for more info)
 
 # Http Error Codes:
+  - 404 Not Found, if the torrent hash is invalid
   - 403 Forbidden, if the client is not authorized
 
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-peers-data
 */
-func (c *Client) GetSyncTorrentPeersData(hash string, rid int) (results map[string]interface{}, err error) {
+func (c *Client) GetSyncTorrentPeersData(hash string, rid int) (results SyncTorrentPeersResponse, err error) {
+	return c.GetSyncTorrentPeersDataCtx(context.Background(), hash, rid)
+}
+
+// GetSyncTorrentPeersDataCtx is like [Client.GetSyncTorrentPeersData] but uses ctx for the underlying requests.
+func (c *Client) GetSyncTorrentPeersDataCtx(ctx context.Context, hash string, rid int) (results SyncTorrentPeersResponse, err error) {
 
 	queryParams := url.Values{}
 	queryParams.Add("hash", hash)
 	queryParams.Add("rid", strconv.Itoa(rid))
 
-	body, err := c.getReq("/api/v2/sync/torrentPeers", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/sync/torrentPeers", &queryParams)
 	if err != nil {
 		return
 	}
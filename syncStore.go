@@ -0,0 +1,367 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// MainData is a consistent snapshot of the data kept by a [MainDataStore].
+type MainData struct {
+	Rid         int                            // Response ID of the last applied update
+	Torrents    map[string]TorrentListResponse // Property: torrent hash, value: same as torrent list
+	Categories  map[string]Category            // Property: category name, value: category info
+	Tags        []string                       // Sorted list of tags
+	Trackers    map[string][]string            // Property: tracker URL, value: list of torrent hashes using the tracker
+	ServerState ServerState                    // Global transfer info
+}
+
+/*
+MainDataStore keeps a local copy of the qBittorrent main data by merging the deltas returned by `/api/v2/sync/maindata`.
+
+It tracks the response ID, resets its state when the server sends a full update, merges partial torrent,
+category and server state updates field by field, and drops removed torrents, categories, tags and trackers.
+
+A MainDataStore is safe for concurrent use by multiple goroutines.
+
+# Example
+
+	store := client.NewMainDataStore()
+
+	err := store.Update(ctx)
+	if err != nil {
+	 panic(err)
+	}
+
+	for hash, torrent := range store.Snapshot().Torrents {
+	 fmt.Println(hash, torrent.Name, torrent.State)
+	}
+*/
+type MainDataStore struct {
+	client *Client
+
+	updateMu sync.Mutex // serializes Update calls so deltas are applied in order
+
+	mu          sync.RWMutex
+	rid         int
+	torrents    map[string]TorrentListResponse
+	categories  map[string]Category
+	tags        map[string]struct{}
+	trackers    map[string][]string
+	serverState ServerState
+}
+
+// rawMainData is used to decode the partial updates of /sync/maindata field by field
+type rawMainData struct {
+	Rid               int                        `json:"rid"`
+	FullUpdate        bool                       `json:"full_update"`
+	Torrents          map[string]json.RawMessage `json:"torrents"`
+	TorrentsRemoved   []string                   `json:"torrents_removed"`
+	Categories        map[string]json.RawMessage `json:"categories"`
+	CategoriesRemoved []string                   `json:"categories_removed"`
+	Tags              []string                   `json:"tags"`
+	TagsRemoved       []string                   `json:"tags_removed"`
+	Trackers          map[string][]string        `json:"trackers"`
+	TrackersRemoved   []string                   `json:"trackers_removed"`
+	ServerState       json.RawMessage            `json:"server_state"`
+}
+
+// NewMainDataStore returns an empty [MainDataStore] that syncs using the client.
+func (c *Client) NewMainDataStore() *MainDataStore {
+	s := &MainDataStore{client: c}
+	s.reset()
+	return s
+}
+
+// Update requests the changes since the last update and merges them into the store.
+func (s *MainDataStore) Update(ctx context.Context) (err error) {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	queryParams := url.Values{}
+	queryParams.Add("rid", strconv.Itoa(s.Rid()))
+
+	body, err := s.client.getReq(ctx, "/api/v2/sync/maindata", &queryParams)
+	if err != nil {
+		return
+	}
+
+	var data rawMainData
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = s.apply(&data)
+	if err != nil {
+		// the store may be half updated, request a full update next time
+		s.reset()
+	}
+
+	return
+}
+
+// Reset drops all the data, the next [MainDataStore.Update] requests a full update.
+func (s *MainDataStore) Reset() {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reset()
+}
+
+// Rid returns the response ID of the last applied update.
+func (s *MainDataStore) Rid() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.rid
+}
+
+// Snapshot returns a copy of the data, it's not modified by later updates.
+func (s *MainDataStore) Snapshot() (results MainData) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results.Rid = s.rid
+	results.ServerState = s.serverState
+
+	results.Torrents = make(map[string]TorrentListResponse, len(s.torrents))
+	for hash, torrent := range s.torrents {
+		results.Torrents[hash] = torrent
+	}
+
+	results.Categories = make(map[string]Category, len(s.categories))
+	for name, category := range s.categories {
+		results.Categories[name] = category
+	}
+
+	results.Tags = make([]string, 0, len(s.tags))
+	for tag := range s.tags {
+		results.Tags = append(results.Tags, tag)
+	}
+	sort.Strings(results.Tags)
+
+	results.Trackers = make(map[string][]string, len(s.trackers))
+	for tracker, hashes := range s.trackers {
+		results.Trackers[tracker] = append([]string(nil), hashes...)
+	}
+
+	return
+}
+
+// Torrent returns a single torrent from the store.
+func (s *MainDataStore) Torrent(hash string) (torrent TorrentListResponse, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	torrent, ok = s.torrents[hash]
+	return
+}
+
+func (s *MainDataStore) reset() {
+	s.rid = 0
+	s.torrents = make(map[string]TorrentListResponse)
+	s.categories = make(map[string]Category)
+	s.tags = make(map[string]struct{})
+	s.trackers = make(map[string][]string)
+	s.serverState = ServerState{}
+}
+
+// apply merges a response into the store, the caller must hold s.mu
+func (s *MainDataStore) apply(data *rawMainData) (err error) {
+	if data.FullUpdate {
+		s.reset()
+	}
+
+	// decoding into the existing value only overwrites the fields present in the update
+	for hash, raw := range data.Torrents {
+		torrent := s.torrents[hash]
+		err = json.Unmarshal(raw, &torrent)
+		if err != nil {
+			return
+		}
+		torrent.Hash = hash
+		s.torrents[hash] = torrent
+	}
+	for _, hash := range data.TorrentsRemoved {
+		delete(s.torrents, hash)
+	}
+
+	for name, raw := range data.Categories {
+		category := s.categories[name]
+		err = json.Unmarshal(raw, &category)
+		if err != nil {
+			return
+		}
+		category.Name = name
+		s.categories[name] = category
+	}
+	for _, name := range data.CategoriesRemoved {
+		delete(s.categories, name)
+	}
+
+	for _, tag := range data.Tags {
+		s.tags[tag] = struct{}{}
+	}
+	for _, tag := range data.TagsRemoved {
+		delete(s.tags, tag)
+	}
+
+	for tracker, hashes := range data.Trackers {
+		s.trackers[tracker] = hashes
+	}
+	for _, tracker := range data.TrackersRemoved {
+		delete(s.trackers, tracker)
+	}
+
+	if len(data.ServerState) > 0 {
+		err = json.Unmarshal(data.ServerState, &s.serverState)
+		if err != nil {
+			return
+		}
+	}
+
+	s.rid = data.Rid
+
+	return
+}
+
+/*
+TorrentPeersStore keeps a local copy of the peers of a single torrent by merging the deltas returned by
+`/api/v2/sync/torrentPeers`, the same way the peers tab of the WebUI does.
+
+A TorrentPeersStore is safe for concurrent use by multiple goroutines.
+*/
+type TorrentPeersStore struct {
+	client *Client
+	hash   string
+
+	updateMu sync.Mutex // serializes Update calls so deltas are applied in order
+
+	mu        sync.RWMutex
+	rid       int
+	showFlags bool
+	peers     map[string]TorrentPeer
+}
+
+// rawTorrentPeers is used to decode the partial updates of /sync/torrentPeers field by field
+type rawTorrentPeers struct {
+	Rid          int                        `json:"rid"`
+	FullUpdate   bool                       `json:"full_update"`
+	ShowFlags    *bool                      `json:"show_flags"`
+	Peers        map[string]json.RawMessage `json:"peers"`
+	PeersRemoved []string                   `json:"peers_removed"`
+}
+
+// NewTorrentPeersStore returns an empty [TorrentPeersStore] for the torrent with the given hash.
+func (c *Client) NewTorrentPeersStore(hash string) *TorrentPeersStore {
+	return &TorrentPeersStore{
+		client: c,
+		hash:   hash,
+		peers:  make(map[string]TorrentPeer),
+	}
+}
+
+// Update requests the peer changes since the last update and merges them into the store.
+func (s *TorrentPeersStore) Update(ctx context.Context) (err error) {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	queryParams := url.Values{}
+	queryParams.Add("hash", s.hash)
+	queryParams.Add("rid", strconv.Itoa(s.Rid()))
+
+	body, err := s.client.getReq(ctx, "/api/v2/sync/torrentPeers", &queryParams)
+	if err != nil {
+		return
+	}
+
+	var data rawTorrentPeers
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = s.apply(&data)
+	if err != nil {
+		// the store may be half updated, request a full update next time
+		s.rid = 0
+		s.peers = make(map[string]TorrentPeer)
+	}
+
+	return
+}
+
+// Hash returns the hash of the tracked torrent.
+func (s *TorrentPeersStore) Hash() string {
+	return s.hash
+}
+
+// Rid returns the response ID of the last applied update.
+func (s *TorrentPeersStore) Rid() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.rid
+}
+
+// ShowFlags reports whether the server asks for the peer flags to be shown.
+func (s *TorrentPeersStore) ShowFlags() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.showFlags
+}
+
+// Peers returns a copy of the peers keyed by their address ("ip:port").
+func (s *TorrentPeersStore) Peers() (results map[string]TorrentPeer) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results = make(map[string]TorrentPeer, len(s.peers))
+	for addr, peer := range s.peers {
+		results[addr] = peer
+	}
+
+	return
+}
+
+// apply merges a response into the store, the caller must hold s.mu
+func (s *TorrentPeersStore) apply(data *rawTorrentPeers) (err error) {
+	if data.FullUpdate {
+		s.peers = make(map[string]TorrentPeer)
+	}
+
+	if data.ShowFlags != nil {
+		s.showFlags = *data.ShowFlags
+	}
+
+	// decoding into the existing value only overwrites the fields present in the update
+	for addr, raw := range data.Peers {
+		peer := s.peers[addr]
+		err = json.Unmarshal(raw, &peer)
+		if err != nil {
+			return
+		}
+		s.peers[addr] = peer
+	}
+	for _, addr := range data.PeersRemoved {
+		delete(s.peers, addr)
+	}
+
+	s.rid = data.Rid
+
+	return
+}
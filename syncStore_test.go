@@ -0,0 +1,162 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+const debianHash = "6a9759bffd5c0af65319979fb7832189f4f3c35d"
+
+// forgetSyncRid makes the server drop the responses it keeps to answer with a delta, so the next update is a full one
+func forgetSyncRid(t *testing.T, sync func() error) {
+	t.Helper()
+
+	for range 20 {
+		if err := sync(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func updateStore(t *testing.T, store interface{ Update(context.Context) error }) {
+	t.Helper()
+
+	if err := store.Update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMainDataStore(t *testing.T) {
+	srv, client := newTestClient(t)
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: ubuntuHash, Name: "ubuntu", Category: "Linux", Tags: "iso", State: qbittorrent.TorrentStateDownloading})
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: debianHash, Name: "debian", State: qbittorrent.TorrentStateUploading})
+
+	store := client.NewMainDataStore()
+	updateStore(t, store)
+
+	data := store.Snapshot()
+	if data.Rid == 0 || len(data.Torrents) != 2 || data.Torrents[ubuntuHash].Name != "ubuntu" || data.Torrents[ubuntuHash].Hash != ubuntuHash {
+		t.Fatalf("got %+v after the first update", data)
+	}
+	if _, ok := data.Categories["Linux"]; !ok || !reflect.DeepEqual(data.Tags, []string{"iso"}) {
+		t.Fatalf("got categories %v and tags %v", data.Categories, data.Tags)
+	}
+	if data.ServerState.ConnectionStatus != qbittorrent.Connected {
+		t.Fatalf("got server state %+v", data.ServerState)
+	}
+
+	// a partial update only has the changed fields, the others are kept
+	srv.UpdateTorrent(ubuntuHash, func(info *qbittorrent.TorrentListResponse) {
+		info.Progress = 0.5
+		info.State = qbittorrent.TorrentStateStalledDL
+	})
+	updateStore(t, store)
+
+	ubuntu, ok := store.Torrent(ubuntuHash)
+	if !ok || ubuntu.Progress != 0.5 || ubuntu.State != qbittorrent.TorrentStateStalledDL || ubuntu.Name != "ubuntu" || ubuntu.Category != "Linux" {
+		t.Fatalf("got %+v after a partial update", ubuntu)
+	}
+	if debian, _ := store.Torrent(debianHash); debian.Name != "debian" || debian.State != qbittorrent.TorrentStateUploading {
+		t.Fatalf("got %+v for the unchanged torrent", debian)
+	}
+
+	srv.RemoveTorrent(debianHash)
+	updateStore(t, store)
+
+	if _, ok := store.Torrent(debianHash); ok {
+		t.Fatal("the removed torrent is still in the store")
+	}
+	// the snapshot is not modified by later updates
+	if _, ok := data.Torrents[debianHash]; !ok {
+		t.Fatal("the update changed an older snapshot")
+	}
+}
+
+func TestMainDataStoreFullUpdate(t *testing.T) {
+	srv, client := newTestClient(t)
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: ubuntuHash, Name: "ubuntu", Tags: "iso"})
+
+	store := client.NewMainDataStore()
+	updateStore(t, store)
+	rid := store.Rid()
+
+	// a full update doesn't list what was removed, the store drops everything it had
+	srv.RemoveTorrent(ubuntuHash)
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: debianHash, Name: "debian"})
+	forgetSyncRid(t, func() error {
+		_, err := client.GetSyncMainData(0)
+		return err
+	})
+	updateStore(t, store)
+
+	data := store.Snapshot()
+	if _, ok := data.Torrents[ubuntuHash]; ok || len(data.Torrents) != 1 || data.Torrents[debianHash].Name != "debian" {
+		t.Fatalf("got torrents %v after a full update", data.Torrents)
+	}
+	if data.Rid <= rid {
+		t.Fatalf("got rid %d, want more than %d", data.Rid, rid)
+	}
+
+	store.Reset()
+	if data := store.Snapshot(); data.Rid != 0 || len(data.Torrents) != 0 {
+		t.Fatalf("got %+v after Reset", data)
+	}
+}
+
+func TestTorrentPeersStore(t *testing.T) {
+	srv, client := newTestClient(t)
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: ubuntuHash, Name: "ubuntu"})
+	srv.SetTorrentPeers(ubuntuHash, map[string]qbittorrent.TorrentPeer{
+		"10.0.0.1:6881": {IP: "10.0.0.1", Port: 6881, Client: "qBittorrent/5.0.0", DlSpeed: 100},
+		"10.0.0.2:6881": {IP: "10.0.0.2", Port: 6881, Client: "Transmission 4.0"},
+	})
+
+	store := client.NewTorrentPeersStore(ubuntuHash)
+	updateStore(t, store)
+
+	if peers := store.Peers(); len(peers) != 2 || peers["10.0.0.1:6881"].Client != "qBittorrent/5.0.0" || !store.ShowFlags() {
+		t.Fatalf("got peers %v after the first update", peers)
+	}
+
+	// the changed peer keeps its other fields, the removed one is dropped
+	srv.SetTorrentPeers(ubuntuHash, map[string]qbittorrent.TorrentPeer{
+		"10.0.0.1:6881": {IP: "10.0.0.1", Port: 6881, Client: "qBittorrent/5.0.0", DlSpeed: 200},
+		"10.0.0.3:6881": {IP: "10.0.0.3", Port: 6881, Client: "Deluge 2.1"},
+	})
+	updateStore(t, store)
+
+	want := map[string]qbittorrent.TorrentPeer{
+		"10.0.0.1:6881": {IP: "10.0.0.1", Port: 6881, Client: "qBittorrent/5.0.0", DlSpeed: 200},
+		"10.0.0.3:6881": {IP: "10.0.0.3", Port: 6881, Client: "Deluge 2.1"},
+	}
+	if peers := store.Peers(); !reflect.DeepEqual(peers, want) {
+		t.Fatalf("got peers %v, want %v", peers, want)
+	}
+
+	// a full update replaces all the peers
+	srv.SetTorrentPeers(ubuntuHash, map[string]qbittorrent.TorrentPeer{"10.0.0.4:6881": {IP: "10.0.0.4", Port: 6881}})
+	forgetSyncRid(t, func() error {
+		_, err := client.GetSyncTorrentPeersData(ubuntuHash, 0)
+		return err
+	})
+	updateStore(t, store)
+
+	if peers := store.Peers(); len(peers) != 1 || peers["10.0.0.4:6881"].IP != "10.0.0.4" {
+		t.Fatalf("got peers %v after a full update", peers)
+	}
+}
+
+func TestTorrentPeersStoreNotFound(t *testing.T) {
+	_, client := newTestClient(t)
+
+	store := client.NewTorrentPeersStore(ubuntuHash)
+	if err := store.Update(context.Background()); err == nil {
+		t.Fatal("got no error for a missing torrent")
+	}
+	if store.Rid() != 0 || store.Hash() != ubuntuHash {
+		t.Fatalf("got rid %d and hash %q", store.Rid(), store.Hash())
+	}
+}
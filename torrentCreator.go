@@ -0,0 +1,224 @@
+package qbittorrent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+/*
+Requires qBittorrent 5.0 or newer, see [Client.Capabilities].
+
+# Params
+  - "formData" the options of the task, create them with [NewTorrentCreatorTask]
+
+# Returns
+  - "taskId" ID of the task, used by the other torrent creator methods
+
+# Http Error Codes
+  - 400 Bad Request, if the options are invalid (e.g. the source path doesn't exist)
+  - 409 Conflict, if too many tasks are queued
+  - 403 Forbidden, if the client is not authorized
+
+# Example
+
+	taskId, err := client.AddTorrentCreatorTask(qbittorrent.NewTorrentCreatorTask("/downloads/ubuntu.iso").
+	 Format(qbittorrent.TorrentFormatHybrid).
+	 Trackers([]string{"udp://tracker.example.org:1337/announce"}).
+	 Private(true).
+	 Data,
+	)
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-5.0)#add-task
+*/
+func (c *Client) AddTorrentCreatorTask(formData map[string]string) (taskId string, err error) {
+	return c.AddTorrentCreatorTaskCtx(context.Background(), formData)
+}
+
+// AddTorrentCreatorTaskCtx is like [Client.AddTorrentCreatorTask] but uses ctx for the underlying requests.
+func (c *Client) AddTorrentCreatorTaskCtx(ctx context.Context, formData map[string]string) (taskId string, err error) {
+	err = c.requireAPIVersion(ctx, "the torrent creator", apiVersionTorrentCreator)
+	if err != nil {
+		return
+	}
+
+	params := url.Values{}
+	for k, v := range formData {
+		params.Add(k, v)
+	}
+
+	body, err := c.postReq(ctx, "/api/v2/torrentcreator/addTask", &params)
+	if err != nil {
+		return
+	}
+
+	result := TorrentCreatorTaskId{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return
+	}
+
+	taskId = result.TaskId
+
+	return
+}
+
+/*
+Requires qBittorrent 5.0 or newer, see [Client.Capabilities].
+
+# Params
+  - "taskId" ID of the task, or an empty string to get all the tasks
+
+# Http Error Codes
+  - 404 Not Found, if the task was not found
+  - 403 Forbidden, if the client is not authorized
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-5.0)#get-task-status
+*/
+func (c *Client) GetTorrentCreatorStatus(taskId string) (results []TorrentCreatorTask, err error) {
+	return c.GetTorrentCreatorStatusCtx(context.Background(), taskId)
+}
+
+// GetTorrentCreatorStatusCtx is like [Client.GetTorrentCreatorStatus] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentCreatorStatusCtx(ctx context.Context, taskId string) (results []TorrentCreatorTask, err error) {
+	err = c.requireAPIVersion(ctx, "the torrent creator", apiVersionTorrentCreator)
+	if err != nil {
+		return
+	}
+
+	params := url.Values{}
+	if taskId != "" {
+		params.Add("taskID", taskId)
+	}
+
+	body, err := c.getReq(ctx, "/api/v2/torrentcreator/status", &params)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(body, &results)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+/*
+Requires qBittorrent 5.0 or newer, see [Client.Capabilities].
+
+# Params
+  - "taskId" ID of a finished task
+
+# Returns
+  - "torrent" the content of the .torrent file
+
+# Http Error Codes
+  - 404 Not Found, if the task was not found
+  - 409 Conflict, if the task is not finished or failed
+  - 403 Forbidden, if the client is not authorized
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-5.0)#get-torrent-file
+*/
+func (c *Client) GetTorrentCreatorFile(taskId string) (torrent []byte, err error) {
+	return c.GetTorrentCreatorFileCtx(context.Background(), taskId)
+}
+
+// GetTorrentCreatorFileCtx is like [Client.GetTorrentCreatorFile] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentCreatorFileCtx(ctx context.Context, taskId string) (torrent []byte, err error) {
+	err = c.requireAPIVersion(ctx, "the torrent creator", apiVersionTorrentCreator)
+	if err != nil {
+		return
+	}
+
+	params := url.Values{}
+	params.Add("taskID", taskId)
+
+	return c.getReq(ctx, "/api/v2/torrentcreator/torrentFile", &params)
+}
+
+/*
+Requires qBittorrent 5.0 or newer, see [Client.Capabilities].
+
+# Params
+  - "taskId" ID of the task to delete, a running task is cancelled
+
+# Http Error Codes
+  - 404 Not Found, if the task was not found
+  - 403 Forbidden, if the client is not authorized
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-5.0)#delete-task
+*/
+func (c *Client) DeleteTorrentCreatorTask(taskId string) (err error) {
+	return c.DeleteTorrentCreatorTaskCtx(context.Background(), taskId)
+}
+
+// DeleteTorrentCreatorTaskCtx is like [Client.DeleteTorrentCreatorTask] but uses ctx for the underlying requests.
+func (c *Client) DeleteTorrentCreatorTaskCtx(ctx context.Context, taskId string) (err error) {
+	err = c.requireAPIVersion(ctx, "the torrent creator", apiVersionTorrentCreator)
+	if err != nil {
+		return
+	}
+
+	params := url.Values{}
+	params.Add("taskID", taskId)
+	_, err = c.postReq(ctx, "/api/v2/torrentcreator/deleteTask", &params)
+	return
+}
+
+/*
+CreateTorrentAndWait adds a torrent creation task, polls its status until it's finished and returns the
+content of the .torrent file. The task is always deleted, even when ctx is cancelled.
+
+A failed task returns an error with the message reported by qBittorrent.
+
+# Example
+
+	torrent, err := client.CreateTorrentAndWait(ctx, qbittorrent.NewTorrentCreatorTask("/downloads/ubuntu").Private(true).Data)
+	if err != nil {
+	 panic(err)
+	}
+
+	err = os.WriteFile("ubuntu.torrent", torrent, 0o644)
+*/
+func (c *Client) CreateTorrentAndWait(ctx context.Context, formData map[string]string) (torrent []byte, err error) {
+	taskId, err := c.AddTorrentCreatorTaskCtx(ctx, formData)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		// the task must be deleted even when ctx is done
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		c.DeleteTorrentCreatorTaskCtx(cleanupCtx, taskId)
+	}()
+
+	for {
+		var tasks []TorrentCreatorTask
+		tasks, err = c.GetTorrentCreatorStatusCtx(ctx, taskId)
+		if err != nil {
+			return
+		}
+		if len(tasks) != 1 {
+			return nil, fmt.Errorf("qbittorrent: torrent creator task %s not found", taskId)
+		}
+
+		switch tasks[0].Status {
+		case TorrentCreatorStatusFinished:
+			return c.GetTorrentCreatorFileCtx(ctx, taskId)
+		case TorrentCreatorStatusFailed:
+			return nil, fmt.Errorf("qbittorrent: creating the torrent failed: %s", tasks[0].ErrorMessage)
+		}
+
+		timer := time.NewTimer(defaultPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/metainfo"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+// checkCreatorTasksDeleted checks that no torrent creator task is left on the server
+func checkCreatorTasksDeleted(t *testing.T, srv *qbittest.Server, client *qbittorrent.Client) {
+	t.Helper()
+
+	if got := srv.Requests("/api/v2/torrentcreator/deleteTask"); got != 1 {
+		t.Fatalf("got %d deleted tasks, want 1", got)
+	}
+	tasks, err := client.GetTorrentCreatorStatus("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 0 {
+		t.Fatalf("got tasks %+v left on the server", tasks)
+	}
+}
+
+func TestCreateTorrentAndWait(t *testing.T) {
+	srv, client := newTestClientVersion(t, apiVersion5)
+
+	task := qbittorrent.NewTorrentCreatorTask("/downloads/ubuntu").
+		Format(qbittorrent.TorrentFormatV1).
+		PieceSize(64 << 10).
+		Private(true).
+		Trackers([]string{"udp://tracker.example.com:1337"}).
+		Comment("created by the test").
+		Source("TEST")
+
+	data, err := client.CreateTorrentAndWait(context.Background(), task.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := metainfo.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Info.Name != "ubuntu" || !meta.Info.Private || meta.Info.Source != "TEST" || meta.Info.PieceLength != 64<<10 ||
+		meta.Announce != "udp://tracker.example.com:1337" || meta.Comment != "created by the test" {
+		t.Fatalf("got %+v", meta)
+	}
+	checkCreatorTasksDeleted(t, srv, client)
+}
+
+func TestCreateTorrentAndWaitFailed(t *testing.T) {
+	srv, client := newTestClientVersion(t, apiVersion5)
+	srv.SetTorrentCreatorError("No such file or directory")
+
+	_, err := client.CreateTorrentAndWait(context.Background(), qbittorrent.NewTorrentCreatorTask("/downloads/missing").Data)
+	if err == nil || !strings.Contains(err.Error(), "No such file or directory") {
+		t.Fatalf("got error %v, want the message of the server", err)
+	}
+	checkCreatorTasksDeleted(t, srv, client)
+}
+
+func TestCreateTorrentAndWaitCancel(t *testing.T) {
+	srv, client := newTestClientVersion(t, apiVersion5)
+	srv.SetTorrentCreatorDuration(time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		waitRequests(t, srv, "/api/v2/torrentcreator/status", 1)
+		cancel()
+	}()
+
+	// the running task is deleted even though ctx is done
+	_, err := client.CreateTorrentAndWait(ctx, qbittorrent.NewTorrentCreatorTask("/downloads/ubuntu").Data)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	checkCreatorTasksDeleted(t, srv, client)
+}
+
+func TestCreateTorrentAndWaitUnsupported(t *testing.T) {
+	srv, client := newTestClientVersion(t, "2.11.0")
+
+	_, err := client.CreateTorrentAndWait(context.Background(), qbittorrent.NewTorrentCreatorTask("/downloads/ubuntu").Data)
+	if !errors.Is(err, qbittorrent.ErrUnsupported) {
+		t.Fatalf("got error %v, want ErrUnsupported", err)
+	}
+	if srv.Requests("/api/v2/torrentcreator/addTask") != 0 {
+		t.Fatal("the task was sent to a server without the torrent creator")
+	}
+}
+
+func TestNewTorrentCreatorTask(t *testing.T) {
+	task := qbittorrent.NewTorrentCreatorTask("/downloads/ubuntu").
+		TorrentFilePath("/torrents/ubuntu.torrent").
+		Format(qbittorrent.TorrentFormatHybrid).
+		PieceSize(1 << 20).
+		OptimizeAlignment(true).
+		PaddedFileSizeLimit(-1).
+		Private(false).
+		Trackers([]string{"udp://a.example.com:1337", "udp://b.example.com:1337"}).
+		WebSeeds([]string{"https://mirror.example.com/ubuntu"}).
+		Comment("comment").
+		Source("source").
+		StartSeeding(false)
+
+	want := map[string]string{
+		"sourcePath":          "/downloads/ubuntu",
+		"torrentFilePath":     "/torrents/ubuntu.torrent",
+		"format":              "hybrid",
+		"pieceSize":           "1048576",
+		"optimizeAlignment":   "true",
+		"paddedFileSizeLimit": "-1",
+		"private":             "false",
+		"trackers":            "udp://a.example.com:1337|udp://b.example.com:1337",
+		"urlSeeds":            "https://mirror.example.com/ubuntu",
+		"comment":             "comment",
+		"source":              "source",
+		"startSeeding":        "false",
+	}
+	for key, value := range want {
+		if task.Data[key] != value {
+			t.Fatalf("%s: got %q, want %q", key, task.Data[key], value)
+		}
+	}
+	if len(task.Data) != len(want) {
+		t.Fatalf("got parameters %v, want %v", task.Data, want)
+	}
+}
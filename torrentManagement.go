@@ -1,9 +1,8 @@
 package qbittorrent
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"mime/multipart"
 	"net/url"
 	"strconv"
 	"strings"
@@ -21,13 +20,22 @@ GetTorrentList - get list of torrents in the client
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-list
 */
 func (c *Client) GetTorrentList(opts *GetTorrentListOptions) (results []TorrentListResponse, err error) {
+	return c.GetTorrentListCtx(context.Background(), opts)
+}
+
+// GetTorrentListCtx is like [Client.GetTorrentList] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentListCtx(ctx context.Context, opts *GetTorrentListOptions) (results []TorrentListResponse, err error) {
 	if opts == nil {
 		opts = &GetTorrentListOptions{}
 	}
 
 	queryParams := url.Values{}
 	if opts.Filter != "" {
-		queryParams.Add("filter", string(opts.Filter))
+		filter, err := c.torrentListFilter(ctx, opts.Filter)
+		if err != nil {
+			return nil, err
+		}
+		queryParams.Add("filter", string(filter))
 	}
 	if opts.Category != nil {
 		queryParams.Add("category", *opts.Category)
@@ -51,7 +59,7 @@ func (c *Client) GetTorrentList(opts *GetTorrentListOptions) (results []TorrentL
 		queryParams.Add("hashes", strings.Join(opts.Hashes, "|"))
 	}
 
-	body, err := c.getReq("/api/v2/torrents/info", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/torrents/info", &queryParams)
 	if err != nil {
 		return
 	}
@@ -80,10 +88,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-generic-properties
 */
 func (c *Client) GetTorrentGenericProperties(hash string) (results TorrentGenericProperties, err error) {
+	return c.GetTorrentGenericPropertiesCtx(context.Background(), hash)
+}
+
+// GetTorrentGenericPropertiesCtx is like [Client.GetTorrentGenericProperties] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentGenericPropertiesCtx(ctx context.Context, hash string) (results TorrentGenericProperties, err error) {
 	queryParams := url.Values{}
 	queryParams.Add("hash", hash)
 
-	body, err := c.getReq("/api/v2/torrents/properties", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/torrents/properties", &queryParams)
 	if err != nil {
 		return
 	}
@@ -109,10 +122,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-trackers
 */
 func (c *Client) GetTorrentTrackers(hash string) (results []TorrentTracker, err error) {
+	return c.GetTorrentTrackersCtx(context.Background(), hash)
+}
+
+// GetTorrentTrackersCtx is like [Client.GetTorrentTrackers] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentTrackersCtx(ctx context.Context, hash string) (results []TorrentTracker, err error) {
 	queryParams := url.Values{}
 	queryParams.Add("hash", hash)
 
-	body, err := c.getReq("/api/v2/torrents/trackers", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/torrents/trackers", &queryParams)
 	if err != nil {
 		return
 	}
@@ -138,10 +156,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-web-seeds
 */
 func (c *Client) GetTorrentWebSeeds(hash string) (results []TorrentSeed, err error) {
+	return c.GetTorrentWebSeedsCtx(context.Background(), hash)
+}
+
+// GetTorrentWebSeedsCtx is like [Client.GetTorrentWebSeeds] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentWebSeedsCtx(ctx context.Context, hash string) (results []TorrentSeed, err error) {
 	queryParams := url.Values{}
 	queryParams.Add("hash", hash)
 
-	body, err := c.getReq("/api/v2/torrents/webseeds", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/torrents/webseeds", &queryParams)
 	if err != nil {
 		return
 	}
@@ -153,6 +176,41 @@ func (c *Client) GetTorrentWebSeeds(hash string) (results []TorrentSeed, err err
 	return
 }
 
+/*
+Requires knowing the torrent hash. You can get it from GetTorrentList
+
+Requires WebAPI v2.8.14 (qBittorrent 4.5.0) or newer, see [Client.Capabilities].
+
+# Params
+  - "hash" The hash of the torrent you want to export
+
+# Returns
+  - "torrent" the content of the .torrent file
+
+# Http Error Codes
+  - 404 Not Found, if the torrent hash is invalid
+  - 409 Conflict, if the metadata of the torrent is not available yet (e.g. a magnet link)
+  - 403 Forbidden, if the client is not authorized
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#export-torrent
+*/
+func (c *Client) ExportTorrent(hash string) (torrent []byte, err error) {
+	return c.ExportTorrentCtx(context.Background(), hash)
+}
+
+// ExportTorrentCtx is like [Client.ExportTorrent] but uses ctx for the underlying requests.
+func (c *Client) ExportTorrentCtx(ctx context.Context, hash string) (torrent []byte, err error) {
+	err = c.requireAPIVersion(ctx, "exporting torrents", apiVersionExportTorrent)
+	if err != nil {
+		return
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("hash", hash)
+
+	return c.getReq(ctx, "/api/v2/torrents/export", &queryParams)
+}
+
 /*
 Get a list of the files of a torrent
 
@@ -169,6 +227,11 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-contents
 */
 func (c *Client) GetTorrentContents(hash string, indexes ...int) (results []TorrentFile, err error) {
+	return c.GetTorrentContentsCtx(context.Background(), hash, indexes...)
+}
+
+// GetTorrentContentsCtx is like [Client.GetTorrentContents] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentContentsCtx(ctx context.Context, hash string, indexes ...int) (results []TorrentFile, err error) {
 	queryParams := url.Values{}
 	queryParams.Add("hash", hash)
 	if len(indexes) > 0 {
@@ -179,7 +242,7 @@ func (c *Client) GetTorrentContents(hash string, indexes ...int) (results []Torr
 		queryParams.Add("indexes", strings.Join(indexesParam, "|"))
 	}
 
-	body, err := c.getReq("/api/v2/torrents/files", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/torrents/files", &queryParams)
 	if err != nil {
 		return
 	}
@@ -205,10 +268,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-pieces-states
 */
 func (c *Client) GetTorrentPiecesStates(hash string) (results []TorrentPiecesState, err error) {
+	return c.GetTorrentPiecesStatesCtx(context.Background(), hash)
+}
+
+// GetTorrentPiecesStatesCtx is like [Client.GetTorrentPiecesStates] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentPiecesStatesCtx(ctx context.Context, hash string) (results []TorrentPiecesState, err error) {
 	queryParams := url.Values{}
 	queryParams.Add("hash", hash)
 
-	body, err := c.getReq("/api/v2/torrents/pieceStates", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/torrents/pieceStates", &queryParams)
 	if err != nil {
 		return
 	}
@@ -234,10 +302,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-pieces-hashes
 */
 func (c *Client) GetTorrentPiecesHashes(hash string) (results []string, err error) {
+	return c.GetTorrentPiecesHashesCtx(context.Background(), hash)
+}
+
+// GetTorrentPiecesHashesCtx is like [Client.GetTorrentPiecesHashes] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentPiecesHashesCtx(ctx context.Context, hash string) (results []string, err error) {
 	queryParams := url.Values{}
 	queryParams.Add("hash", hash)
 
-	body, err := c.getReq("/api/v2/torrents/pieceHashes", &queryParams)
+	body, err := c.getReq(ctx, "/api/v2/torrents/pieceHashes", &queryParams)
 	if err != nil {
 		return
 	}
@@ -253,6 +326,8 @@ func (c *Client) GetTorrentPiecesHashes(hash string) (results []string, err erro
 /*
 Requires knowing the torrent hash. You can get it from GetTorrentList
 
+Uses `/api/v2/torrents/stop` on qBittorrent 5.0 and newer, see [Client.Capabilities].
+
 # Params
   - "hashes" The hashes of the torrents you want to pause. or set to []string{"all"}, to pause all torrents.
 
@@ -262,15 +337,54 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#pause-torrents
 */
 func (c *Client) PauseTorrents(hashes []string) (err error) {
+	return c.PauseTorrentsCtx(context.Background(), hashes)
+}
+
+// PauseTorrentsCtx is like [Client.PauseTorrents] but uses ctx for the underlying requests.
+func (c *Client) PauseTorrentsCtx(ctx context.Context, hashes []string) (err error) {
+	return c.StopTorrentsCtx(ctx, hashes)
+}
+
+/*
+Requires knowing the torrent hash. You can get it from GetTorrentList
+
+Uses `/api/v2/torrents/pause` before qBittorrent 5.0, see [Client.Capabilities].
+
+# Params
+  - "hashes" The hashes of the torrents you want to stop. or set to []string{"all"}, to stop all torrents.
+
+# Http Error Codes
+  - 403 Forbidden, if the client is not authorized
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-5.0)#stop-torrents
+*/
+func (c *Client) StopTorrents(hashes []string) (err error) {
+	return c.StopTorrentsCtx(context.Background(), hashes)
+}
+
+// StopTorrentsCtx is like [Client.StopTorrents] but uses ctx for the underlying requests.
+func (c *Client) StopTorrentsCtx(ctx context.Context, hashes []string) (err error) {
+	caps, err := c.CapabilitiesCtx(ctx)
+	if err != nil {
+		return
+	}
+
+	endpoint := "/api/v2/torrents/pause"
+	if caps.StopStart {
+		endpoint = "/api/v2/torrents/stop"
+	}
+
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/pause", &params)
+	_, err = c.postReq(ctx, endpoint, &params)
 	return
 }
 
 /*
 Requires knowing the torrent hash. You can get it from GetTorrentList
 
+Uses `/api/v2/torrents/start` on qBittorrent 5.0 and newer, see [Client.Capabilities].
+
 #Params
   - "hashes" The hashes of the torrents you want to resume. or set to []string{"all"}, to resume all torrents.
 
@@ -280,9 +394,46 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#resume-torrents
 */
 func (c *Client) ResumeTorrents(hashes []string) (err error) {
+	return c.ResumeTorrentsCtx(context.Background(), hashes)
+}
+
+// ResumeTorrentsCtx is like [Client.ResumeTorrents] but uses ctx for the underlying requests.
+func (c *Client) ResumeTorrentsCtx(ctx context.Context, hashes []string) (err error) {
+	return c.StartTorrentsCtx(ctx, hashes)
+}
+
+/*
+Requires knowing the torrent hash. You can get it from GetTorrentList
+
+Uses `/api/v2/torrents/resume` before qBittorrent 5.0, see [Client.Capabilities].
+
+# Params
+  - "hashes" The hashes of the torrents you want to start. or set to []string{"all"}, to start all torrents.
+
+# Http Error Codes
+  - 403 Forbidden, if the client is not authorized
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-5.0)#start-torrents
+*/
+func (c *Client) StartTorrents(hashes []string) (err error) {
+	return c.StartTorrentsCtx(context.Background(), hashes)
+}
+
+// StartTorrentsCtx is like [Client.StartTorrents] but uses ctx for the underlying requests.
+func (c *Client) StartTorrentsCtx(ctx context.Context, hashes []string) (err error) {
+	caps, err := c.CapabilitiesCtx(ctx)
+	if err != nil {
+		return
+	}
+
+	endpoint := "/api/v2/torrents/resume"
+	if caps.StopStart {
+		endpoint = "/api/v2/torrents/start"
+	}
+
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/resume", &params)
+	_, err = c.postReq(ctx, endpoint, &params)
 	return
 }
 
@@ -299,10 +450,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#delete-torrents
 */
 func (c *Client) DeleteTorrents(hashes []string, deleteFiles bool) (err error) {
+	return c.DeleteTorrentsCtx(context.Background(), hashes, deleteFiles)
+}
+
+// DeleteTorrentsCtx is like [Client.DeleteTorrents] but uses ctx for the underlying requests.
+func (c *Client) DeleteTorrentsCtx(ctx context.Context, hashes []string, deleteFiles bool) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("deleteFiles", strconv.FormatBool(deleteFiles))
-	_, err = c.postReq("/api/v2/torrents/delete", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/delete", &params)
 	return
 }
 
@@ -318,9 +474,14 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#recheck-torrents
 */
 func (c *Client) RecheckTorrents(hashes []string) (err error) {
+	return c.RecheckTorrentsCtx(context.Background(), hashes)
+}
+
+// RecheckTorrentsCtx is like [Client.RecheckTorrents] but uses ctx for the underlying requests.
+func (c *Client) RecheckTorrentsCtx(ctx context.Context, hashes []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/recheck", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/recheck", &params)
 	return
 }
 
@@ -336,9 +497,14 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#reannounce-torrents
 */
 func (c *Client) ReannounceTorrents(hashes []string) (err error) {
+	return c.ReannounceTorrentsCtx(context.Background(), hashes)
+}
+
+// ReannounceTorrentsCtx is like [Client.ReannounceTorrents] but uses ctx for the underlying requests.
+func (c *Client) ReannounceTorrentsCtx(ctx context.Context, hashes []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/reannounce", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/reannounce", &params)
 	return
 }
 
@@ -363,6 +529,15 @@ This method can add torrents from a local file or from URLs. http://, https://,
 		panic(err)
 	}
 
+The options are checked with [NewTorrentOptions.Validate] first, and root_folder/contentLayout are translated
+to the parameter known by the server.
+
+The torrents added with [NewTorrentOptions.AddFromBytes] and [NewTorrentOptions.AddFromReader] are not part of
+Data, passing it fails with [ErrInvalidOptions]: use [Client.AddNewTorrentWithOptions] to send them.
+
+qBittorrent answers "Fails." when none of the torrents was added, e.g. when they are all already in
+qBittorrent, which is returned as [ErrAddFailed].
+
 # Http Error Codes
   - 415 Torrent file is not valid
   - 403 Forbidden, if the client is not authorized
@@ -370,41 +545,86 @@ This method can add torrents from a local file or from URLs. http://, https://,
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#add-new-torrent
 */
 func (c *Client) AddNewTorrent(formData map[string]string) (err error) {
-	// add torrent from files and urls
-	if files, ok := formData["torrents"]; ok {
-		filePaths := strings.Split(files, "\n")
+	return c.AddNewTorrentCtx(context.Background(), formData)
+}
+
+// AddNewTorrentCtx is like [Client.AddNewTorrent] but uses ctx for the underlying requests.
+func (c *Client) AddNewTorrentCtx(ctx context.Context, formData map[string]string) (err error) {
+	return c.AddNewTorrentWithOptionsCtx(ctx, &NewTorrentOptions{Data: formData})
+}
 
-		var buffer bytes.Buffer
-		writer := multipart.NewWriter(&buffer)
+/*
+AddNewTorrentWithOptions is like [Client.AddNewTorrent] but takes the options themselves, which also sends the
+torrents added with [NewTorrentOptions.AddFromBytes] and [NewTorrentOptions.AddFromReader].
 
-		for _, filePath := range filePaths {
-			c.addFileToWriter(writer, filePath)
-		}
+The .torrent files are streamed to the server, so adding many files doesn't load them all in memory.
 
-		for key, val := range formData {
-			if key != "torrents" {
-				writer.WriteField(key, val)
-			}
+# Example
+
+	torrent := qbittorrent.NewTorrent().
+		AddFromBytes("ubuntu.torrent", data).
+		AddFromReader("debian.torrent", resp.Body).
+		Category("Linux")
+
+	err = client.AddNewTorrentWithOptions(torrent)
+*/
+func (c *Client) AddNewTorrentWithOptions(opts *NewTorrentOptions) (err error) {
+	return c.AddNewTorrentWithOptionsCtx(context.Background(), opts)
+}
+
+// AddNewTorrentWithOptionsCtx is like [Client.AddNewTorrentWithOptions] but uses ctx for the underlying requests.
+func (c *Client) AddNewTorrentWithOptionsCtx(ctx context.Context, opts *NewTorrentOptions) (err error) {
+	err = opts.Validate()
+	if err != nil {
+		return
+	}
+
+	formData, err := c.adaptNewTorrent(ctx, opts.Data)
+	if err != nil {
+		return
+	}
+
+	files, err := opts.torrentFiles()
+	if err != nil {
+		return
+	}
+
+	// add torrent from url only
+	if len(files) == 0 {
+		params := url.Values{}
+		for k, v := range formData {
+			params.Add(k, v)
 		}
 
-		err = writer.Close()
+		body, err := c.postReq(ctx, "/api/v2/torrents/add", &params)
 		if err != nil {
-			return
+			return err
 		}
+		return addResponseError(body)
+	}
 
-		_, err = c.postMultipart("/api/v2/torrents/add", buffer, writer.FormDataContentType())
+	// add torrent from files and urls
+	fields := make(map[string]string, len(formData))
+	for key, val := range formData {
+		if key != "torrents" {
+			fields[key] = val
+		}
+	}
 
+	body, err := c.postMultipart(ctx, "/api/v2/torrents/add", fields, files)
+	if err != nil {
 		return
 	}
 
-	// add torrent from url only
-	params := url.Values{}
-	for k, v := range formData {
-		params.Add(k, v)
-	}
+	return addResponseError(body)
+}
 
-	_, err = c.postReq("/api/v2/torrents/add", &params)
-	return
+// addResponseError returns ErrAddFailed when qBittorrent answered the add request with 200 "Fails."
+func addResponseError(body []byte) error {
+	if strings.TrimSpace(string(body)) == "Fails." {
+		return ErrAddFailed
+	}
+	return nil
 }
 
 /*
@@ -421,10 +641,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#add-trackers-to-torrent
 */
 func (c *Client) AddTrackersToTorrent(hash string, trackers []string) (err error) {
+	return c.AddTrackersToTorrentCtx(context.Background(), hash, trackers)
+}
+
+// AddTrackersToTorrentCtx is like [Client.AddTrackersToTorrent] but uses ctx for the underlying requests.
+func (c *Client) AddTrackersToTorrentCtx(ctx context.Context, hash string, trackers []string) (err error) {
 	params := url.Values{}
 	params.Add("hash", hash)
 	params.Add("urls", strings.Join(trackers, "\n"))
-	_, err = c.postReq("/api/v2/torrents/addTrackers", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/addTrackers", &params)
 	return
 }
 
@@ -446,11 +671,16 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#edit-trackers
 */
 func (c *Client) EditTrackers(hash, origUrl, newUrl string) (err error) {
+	return c.EditTrackersCtx(context.Background(), hash, origUrl, newUrl)
+}
+
+// EditTrackersCtx is like [Client.EditTrackers] but uses ctx for the underlying requests.
+func (c *Client) EditTrackersCtx(ctx context.Context, hash, origUrl, newUrl string) (err error) {
 	params := url.Values{}
 	params.Add("hash", hash)
 	params.Add("origUrl", origUrl)
 	params.Add("newUrl", newUrl)
-	_, err = c.postReq("/api/v2/torrents/editTracker", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/editTracker", &params)
 	return
 }
 
@@ -469,10 +699,120 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#remove-trackers
 */
 func (c *Client) RemoveTrackers(hash string, urls []string) (err error) {
+	return c.RemoveTrackersCtx(context.Background(), hash, urls)
+}
+
+// RemoveTrackersCtx is like [Client.RemoveTrackers] but uses ctx for the underlying requests.
+func (c *Client) RemoveTrackersCtx(ctx context.Context, hash string, urls []string) (err error) {
+	params := url.Values{}
+	params.Add("hash", hash)
+	params.Add("urls", strings.Join(urls, "|"))
+	_, err = c.postReq(ctx, "/api/v2/torrents/removeTrackers", &params)
+	return
+}
+
+/*
+Requires knowing the torrent hash. You can get it from GetTorrentList
+
+Requires WebAPI v2.11.3 (qBittorrent 5.1) or newer, see [Client.Capabilities].
+
+# Params
+  - "hash" The hash of the torrent you want to add web seeds to.
+  - "urls" The URLs of the web seeds you want to add.
+
+# Http Error Codes
+  - 400 One of the urls is not a valid URL
+  - 404 Torrent hash was not found
+  - 403 Forbidden, if the client is not authorized
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-5.0)#add-web-seeds
+*/
+func (c *Client) AddWebSeeds(hash string, urls []string) (err error) {
+	return c.AddWebSeedsCtx(context.Background(), hash, urls)
+}
+
+// AddWebSeedsCtx is like [Client.AddWebSeeds] but uses ctx for the underlying requests.
+func (c *Client) AddWebSeedsCtx(ctx context.Context, hash string, urls []string) (err error) {
+	err = c.requireAPIVersion(ctx, "editing web seeds", apiVersionWebSeedEditing)
+	if err != nil {
+		return
+	}
+
 	params := url.Values{}
 	params.Add("hash", hash)
 	params.Add("urls", strings.Join(urls, "|"))
-	_, err = c.postReq("/api/v2/torrents/removeTrackers", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/addWebSeeds", &params)
+	return
+}
+
+/*
+Requires knowing the torrent hash. You can get it from GetTorrentList
+
+Requires WebAPI v2.11.3 (qBittorrent 5.1) or newer, see [Client.Capabilities].
+
+# Params
+  - "hash" The hash of the torrent you want to edit a web seed for.
+  - "origUrl" The web seed URL you want to edit.
+  - "newUrl" The new web seed URL to replace the "origUrl"
+
+# Http Error Codes
+  - 400 newUrl is not a valid URL
+  - 404 Torrent hash was not found
+  - 409 origUrl was not found
+  - 403 Forbidden, if the client is not authorized
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-5.0)#edit-web-seed
+*/
+func (c *Client) EditWebSeed(hash, origUrl, newUrl string) (err error) {
+	return c.EditWebSeedCtx(context.Background(), hash, origUrl, newUrl)
+}
+
+// EditWebSeedCtx is like [Client.EditWebSeed] but uses ctx for the underlying requests.
+func (c *Client) EditWebSeedCtx(ctx context.Context, hash, origUrl, newUrl string) (err error) {
+	err = c.requireAPIVersion(ctx, "editing web seeds", apiVersionWebSeedEditing)
+	if err != nil {
+		return
+	}
+
+	params := url.Values{}
+	params.Add("hash", hash)
+	params.Add("origUrl", origUrl)
+	params.Add("newUrl", newUrl)
+	_, err = c.postReq(ctx, "/api/v2/torrents/editWebSeed", &params)
+	return
+}
+
+/*
+Requires knowing the torrent hash. You can get it from GetTorrentList
+
+Requires WebAPI v2.11.3 (qBittorrent 5.1) or newer, see [Client.Capabilities].
+
+# Params
+  - "hash" The hash of the torrent you want to remove web seeds from.
+  - "urls" The URLs of the web seeds you want to remove.
+
+# Http Error Codes
+  - 400 One of the urls is not a valid URL
+  - 404 Torrent hash was not found
+  - 403 Forbidden, if the client is not authorized
+
+https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-5.0)#remove-web-seeds
+*/
+func (c *Client) RemoveWebSeeds(hash string, urls []string) (err error) {
+	return c.RemoveWebSeedsCtx(context.Background(), hash, urls)
+}
+
+// RemoveWebSeedsCtx is like [Client.RemoveWebSeeds] but uses ctx for the underlying requests.
+func (c *Client) RemoveWebSeedsCtx(ctx context.Context, hash string, urls []string) (err error) {
+	err = c.requireAPIVersion(ctx, "editing web seeds", apiVersionWebSeedEditing)
+	if err != nil {
+		return
+	}
+
+	params := url.Values{}
+	params.Add("hash", hash)
+	params.Add("urls", strings.Join(urls, "|"))
+	_, err = c.postReq(ctx, "/api/v2/torrents/removeWebSeeds", &params)
 	return
 }
 
@@ -490,10 +830,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#add-peers
 */
 func (c *Client) AddPeers(hashes, peers []string) (err error) {
+	return c.AddPeersCtx(context.Background(), hashes, peers)
+}
+
+// AddPeersCtx is like [Client.AddPeers] but uses ctx for the underlying requests.
+func (c *Client) AddPeersCtx(ctx context.Context, hashes, peers []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("peers", strings.Join(peers, "|"))
-	_, err = c.postReq("/api/v2/torrents/addPeers", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/addPeers", &params)
 	return
 }
 
@@ -510,9 +855,14 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#increase-torrent-priority
 */
 func (c *Client) IncreaseTorrentPriority(hashes []string) (err error) {
+	return c.IncreaseTorrentPriorityCtx(context.Background(), hashes)
+}
+
+// IncreaseTorrentPriorityCtx is like [Client.IncreaseTorrentPriority] but uses ctx for the underlying requests.
+func (c *Client) IncreaseTorrentPriorityCtx(ctx context.Context, hashes []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/increasePrio", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/increasePrio", &params)
 	return
 }
 
@@ -529,9 +879,14 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#decrease-torrent-priority
 */
 func (c *Client) DecreaseTorrentPriority(hashes []string) (err error) {
+	return c.DecreaseTorrentPriorityCtx(context.Background(), hashes)
+}
+
+// DecreaseTorrentPriorityCtx is like [Client.DecreaseTorrentPriority] but uses ctx for the underlying requests.
+func (c *Client) DecreaseTorrentPriorityCtx(ctx context.Context, hashes []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/decreasePrio", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/decreasePrio", &params)
 	return
 }
 
@@ -548,9 +903,14 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#maximal-torrent-priority
 */
 func (c *Client) MaximalTorrentPriority(hashes []string) (err error) {
+	return c.MaximalTorrentPriorityCtx(context.Background(), hashes)
+}
+
+// MaximalTorrentPriorityCtx is like [Client.MaximalTorrentPriority] but uses ctx for the underlying requests.
+func (c *Client) MaximalTorrentPriorityCtx(ctx context.Context, hashes []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/topPrio", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/topPrio", &params)
 	return
 }
 
@@ -567,9 +927,14 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#minimal-torrent-priority
 */
 func (c *Client) MinimalTorrentPriority(hashes []string) (err error) {
+	return c.MinimalTorrentPriorityCtx(context.Background(), hashes)
+}
+
+// MinimalTorrentPriorityCtx is like [Client.MinimalTorrentPriority] but uses ctx for the underlying requests.
+func (c *Client) MinimalTorrentPriorityCtx(ctx context.Context, hashes []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/bottomPrio", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/bottomPrio", &params)
 	return
 }
 
@@ -592,6 +957,11 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-file-priority
 */
 func (c *Client) SetFilePriority(hash string, ids []int, priority FilePriority) (err error) {
+	return c.SetFilePriorityCtx(context.Background(), hash, ids, priority)
+}
+
+// SetFilePriorityCtx is like [Client.SetFilePriority] but uses ctx for the underlying requests.
+func (c *Client) SetFilePriorityCtx(ctx context.Context, hash string, ids []int, priority FilePriority) (err error) {
 	strIDs := make([]string, len(ids))
 	for i, id := range ids {
 		strIDs[i] = strconv.Itoa(id)
@@ -602,7 +972,7 @@ func (c *Client) SetFilePriority(hash string, ids []int, priority FilePriority)
 	params.Add("id", strings.Join(strIDs, "|"))
 	params.Add("priority", strconv.Itoa(int(priority)))
 
-	_, err = c.postReq("/api/v2/torrents/filePrio", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/filePrio", &params)
 	return
 }
 
@@ -621,10 +991,15 @@ Returns:
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-download-limit
 */
 func (c *Client) GetTorrentDownloadLimit(hashes []string) (results map[string]int, err error) {
+	return c.GetTorrentDownloadLimitCtx(context.Background(), hashes)
+}
+
+// GetTorrentDownloadLimitCtx is like [Client.GetTorrentDownloadLimit] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentDownloadLimitCtx(ctx context.Context, hashes []string) (results map[string]int, err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 
-	body, err := c.getReq("/api/v2/torrents/downloadLimit", &params)
+	body, err := c.getReq(ctx, "/api/v2/torrents/downloadLimit", &params)
 	if err != nil {
 		return
 	}
@@ -647,10 +1022,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-torrent-download-limit
 */
 func (c *Client) SetTorrentDownloadLimit(hashes []string, limit int) (err error) {
+	return c.SetTorrentDownloadLimitCtx(context.Background(), hashes, limit)
+}
+
+// SetTorrentDownloadLimitCtx is like [Client.SetTorrentDownloadLimit] but uses ctx for the underlying requests.
+func (c *Client) SetTorrentDownloadLimitCtx(ctx context.Context, hashes []string, limit int) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("limit", strconv.Itoa(limit))
-	_, err = c.postReq("/api/v2/torrents/setDownloadLimit", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/setDownloadLimit", &params)
 	return
 }
 
@@ -670,12 +1050,17 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-torrent-share-limit
 */
 func (c *Client) SetTorrentShareLimit(hashes []string, ratioLimit float64, seedingTimeLimit, inactiveSeedingTimeLimit int) (err error) {
+	return c.SetTorrentShareLimitCtx(context.Background(), hashes, ratioLimit, seedingTimeLimit, inactiveSeedingTimeLimit)
+}
+
+// SetTorrentShareLimitCtx is like [Client.SetTorrentShareLimit] but uses ctx for the underlying requests.
+func (c *Client) SetTorrentShareLimitCtx(ctx context.Context, hashes []string, ratioLimit float64, seedingTimeLimit, inactiveSeedingTimeLimit int) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("ratioLimit", strconv.FormatFloat(ratioLimit, 'f', -1, 64))
 	params.Add("seedingTimeLimit", strconv.Itoa(seedingTimeLimit))
 	params.Add("inactiveSeedingTimeLimit", strconv.Itoa(inactiveSeedingTimeLimit))
-	_, err = c.postReq("/api/v2/torrents/setShareLimits", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/setShareLimits", &params)
 	return
 }
 
@@ -694,10 +1079,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-torrent-upload-limit
 */
 func (c *Client) GetTorrentUploadLimit(hashes []string) (results map[string]int, err error) {
+	return c.GetTorrentUploadLimitCtx(context.Background(), hashes)
+}
+
+// GetTorrentUploadLimitCtx is like [Client.GetTorrentUploadLimit] but uses ctx for the underlying requests.
+func (c *Client) GetTorrentUploadLimitCtx(ctx context.Context, hashes []string) (results map[string]int, err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 
-	body, err := c.getReq("/api/v2/torrents/uploadLimit", &params)
+	body, err := c.getReq(ctx, "/api/v2/torrents/uploadLimit", &params)
 	if err != nil {
 		return
 	}
@@ -720,10 +1110,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-torrent-upload-limit
 */
 func (c *Client) SetTorrentUploadLimit(hashes []string, limit int) (err error) {
+	return c.SetTorrentUploadLimitCtx(context.Background(), hashes, limit)
+}
+
+// SetTorrentUploadLimitCtx is like [Client.SetTorrentUploadLimit] but uses ctx for the underlying requests.
+func (c *Client) SetTorrentUploadLimitCtx(ctx context.Context, hashes []string, limit int) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("limit", strconv.Itoa(limit))
-	_, err = c.postReq("/api/v2/torrents/setUploadLimit", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/setUploadLimit", &params)
 	return
 }
 
@@ -743,10 +1138,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-torrent-location
 */
 func (c *Client) SetTorrentLocation(hashes []string, location string) (err error) {
+	return c.SetTorrentLocationCtx(context.Background(), hashes, location)
+}
+
+// SetTorrentLocationCtx is like [Client.SetTorrentLocation] but uses ctx for the underlying requests.
+func (c *Client) SetTorrentLocationCtx(ctx context.Context, hashes []string, location string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("location", location)
-	_, err = c.postReq("/api/v2/torrents/setLocation", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/setLocation", &params)
 	return
 }
 
@@ -767,10 +1167,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-torrent-name
 */
 func (c *Client) SetTorrentName(hash, name string) (err error) {
+	return c.SetTorrentNameCtx(context.Background(), hash, name)
+}
+
+// SetTorrentNameCtx is like [Client.SetTorrentName] but uses ctx for the underlying requests.
+func (c *Client) SetTorrentNameCtx(ctx context.Context, hash, name string) (err error) {
 	params := url.Values{}
 	params.Add("hash", hash)
 	params.Add("name", name)
-	_, err = c.postReq("/api/v2/torrents/rename", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/rename", &params)
 	return
 }
 
@@ -788,10 +1193,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-torrent-category
 */
 func (c *Client) SetTorrentCategory(hashes []string, category string) (err error) {
+	return c.SetTorrentCategoryCtx(context.Background(), hashes, category)
+}
+
+// SetTorrentCategoryCtx is like [Client.SetTorrentCategory] but uses ctx for the underlying requests.
+func (c *Client) SetTorrentCategoryCtx(ctx context.Context, hashes []string, category string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("category", category)
-	_, err = c.postReq("/api/v2/torrents/setCategory", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/setCategory", &params)
 	return
 }
 
@@ -806,7 +1216,12 @@ Returns all categories in the client
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-all-categories
 */
 func (c *Client) GetAllCategories() (results map[string]Category, err error) {
-	body, err := c.getReq("/api/v2/torrents/categories", nil)
+	return c.GetAllCategoriesCtx(context.Background())
+}
+
+// GetAllCategoriesCtx is like [Client.GetAllCategories] but uses ctx for the underlying requests.
+func (c *Client) GetAllCategoriesCtx(ctx context.Context) (results map[string]Category, err error) {
+	body, err := c.getReq(ctx, "/api/v2/torrents/categories", nil)
 	if err != nil {
 		return
 	}
@@ -830,10 +1245,15 @@ Adds a new category to the client
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#add-new-category
 */
 func (c *Client) AddNewCategory(name, savePath string) (err error) {
+	return c.AddNewCategoryCtx(context.Background(), name, savePath)
+}
+
+// AddNewCategoryCtx is like [Client.AddNewCategory] but uses ctx for the underlying requests.
+func (c *Client) AddNewCategoryCtx(ctx context.Context, name, savePath string) (err error) {
 	params := url.Values{}
 	params.Add("category", name)
 	params.Add("savePath", savePath)
-	_, err = c.postReq("/api/v2/torrents/createCategory", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/createCategory", &params)
 	return
 }
 
@@ -852,10 +1272,15 @@ Edits an existing category in the client
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#edit-category
 */
 func (c *Client) EditCategory(name, savePath string) (err error) {
+	return c.EditCategoryCtx(context.Background(), name, savePath)
+}
+
+// EditCategoryCtx is like [Client.EditCategory] but uses ctx for the underlying requests.
+func (c *Client) EditCategoryCtx(ctx context.Context, name, savePath string) (err error) {
 	params := url.Values{}
 	params.Add("category", name)
 	params.Add("savePath", savePath)
-	_, err = c.postReq("/api/v2/torrents/editCategory", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/editCategory", &params)
 	return
 }
 
@@ -871,9 +1296,14 @@ Removes categories from the client
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#remove-categories
 */
 func (c *Client) RemoveCategories(categories []string) (err error) {
+	return c.RemoveCategoriesCtx(context.Background(), categories)
+}
+
+// RemoveCategoriesCtx is like [Client.RemoveCategories] but uses ctx for the underlying requests.
+func (c *Client) RemoveCategoriesCtx(ctx context.Context, categories []string) (err error) {
 	params := url.Values{}
 	params.Add("categories", strings.Join(categories, "\n"))
-	_, err = c.postReq("/api/v2/torrents/removeCategories", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/removeCategories", &params)
 	return
 }
 
@@ -892,10 +1322,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#add-torrent-tags
 */
 func (c *Client) AddTorrentTags(hashes, tags []string) (err error) {
+	return c.AddTorrentTagsCtx(context.Background(), hashes, tags)
+}
+
+// AddTorrentTagsCtx is like [Client.AddTorrentTags] but uses ctx for the underlying requests.
+func (c *Client) AddTorrentTagsCtx(ctx context.Context, hashes, tags []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("tags", strings.Join(tags, ","))
-	_, err = c.postReq("/api/v2/torrents/addTags", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/addTags", &params)
 	return
 }
 
@@ -914,10 +1349,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#remove-torrent-tags
 */
 func (c *Client) RemoveTorrentTags(hashes, tags []string) (err error) {
+	return c.RemoveTorrentTagsCtx(context.Background(), hashes, tags)
+}
+
+// RemoveTorrentTagsCtx is like [Client.RemoveTorrentTags] but uses ctx for the underlying requests.
+func (c *Client) RemoveTorrentTagsCtx(ctx context.Context, hashes, tags []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("tags", strings.Join(tags, ","))
-	_, err = c.postReq("/api/v2/torrents/removeTags", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/removeTags", &params)
 	return
 }
 
@@ -930,7 +1370,12 @@ Returns all tags
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-all-tags
 */
 func (c *Client) GetAllTags() (results []string, err error) {
-	body, err := c.getReq("/api/v2/torrents/tags", nil)
+	return c.GetAllTagsCtx(context.Background())
+}
+
+// GetAllTagsCtx is like [Client.GetAllTags] but uses ctx for the underlying requests.
+func (c *Client) GetAllTagsCtx(ctx context.Context) (results []string, err error) {
+	body, err := c.getReq(ctx, "/api/v2/torrents/tags", nil)
 	if err != nil {
 		return
 	}
@@ -951,9 +1396,14 @@ Creates new tags in the client
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#create-tags
 */
 func (c *Client) CreateTags(tags []string) (err error) {
+	return c.CreateTagsCtx(context.Background(), tags)
+}
+
+// CreateTagsCtx is like [Client.CreateTags] but uses ctx for the underlying requests.
+func (c *Client) CreateTagsCtx(ctx context.Context, tags []string) (err error) {
 	params := url.Values{}
 	params.Add("tags", strings.Join(tags, ","))
-	_, err = c.postReq("/api/v2/torrents/createTags", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/createTags", &params)
 	return
 }
 
@@ -969,9 +1419,14 @@ Deletes tags from the client
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#delete-tags
 */
 func (c *Client) DeleteTags(tags []string) (err error) {
+	return c.DeleteTagsCtx(context.Background(), tags)
+}
+
+// DeleteTagsCtx is like [Client.DeleteTags] but uses ctx for the underlying requests.
+func (c *Client) DeleteTagsCtx(ctx context.Context, tags []string) (err error) {
 	params := url.Values{}
 	params.Add("tags", strings.Join(tags, ","))
-	_, err = c.postReq("/api/v2/torrents/deleteTags", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/deleteTags", &params)
 	return
 }
 
@@ -990,10 +1445,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-automatic-torrent-management
 */
 func (c *Client) SetAutomaticTorrentManagement(hashes []string, enable bool) (err error) {
+	return c.SetAutomaticTorrentManagementCtx(context.Background(), hashes, enable)
+}
+
+// SetAutomaticTorrentManagementCtx is like [Client.SetAutomaticTorrentManagement] but uses ctx for the underlying requests.
+func (c *Client) SetAutomaticTorrentManagementCtx(ctx context.Context, hashes []string, enable bool) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("enable", strconv.FormatBool(enable))
-	_, err = c.postReq("/api/v2/torrents/setAutoManagement", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/setAutoManagement", &params)
 	return
 }
 
@@ -1009,9 +1469,14 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#toggle-sequential-download
 */
 func (c *Client) ToggleSequentialDownload(hashes []string) (err error) {
+	return c.ToggleSequentialDownloadCtx(context.Background(), hashes)
+}
+
+// ToggleSequentialDownloadCtx is like [Client.ToggleSequentialDownload] but uses ctx for the underlying requests.
+func (c *Client) ToggleSequentialDownloadCtx(ctx context.Context, hashes []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/toggleSequentialDownload", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/toggleSequentialDownload", &params)
 	return
 }
 
@@ -1027,9 +1492,14 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-firstlast-piece-priority
 */
 func (c *Client) ToggleFirstLastPiecePriority(hashes []string) (err error) {
+	return c.ToggleFirstLastPiecePriorityCtx(context.Background(), hashes)
+}
+
+// ToggleFirstLastPiecePriorityCtx is like [Client.ToggleFirstLastPiecePriority] but uses ctx for the underlying requests.
+func (c *Client) ToggleFirstLastPiecePriorityCtx(ctx context.Context, hashes []string) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
-	_, err = c.postReq("/api/v2/torrents/toggleFirstLastPiecePrio", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/toggleFirstLastPiecePrio", &params)
 	return
 }
 
@@ -1046,10 +1516,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-force-start
 */
 func (c *Client) SetForceStart(hashes []string, enable bool) (err error) {
+	return c.SetForceStartCtx(context.Background(), hashes, enable)
+}
+
+// SetForceStartCtx is like [Client.SetForceStart] but uses ctx for the underlying requests.
+func (c *Client) SetForceStartCtx(ctx context.Context, hashes []string, enable bool) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("value", strconv.FormatBool(enable))
-	_, err = c.postReq("/api/v2/torrents/setForceStart", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/setForceStart", &params)
 	return
 }
 
@@ -1066,10 +1541,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-super-seeding
 */
 func (c *Client) SetSuperSeeding(hashes []string, enable bool) (err error) {
+	return c.SetSuperSeedingCtx(context.Background(), hashes, enable)
+}
+
+// SetSuperSeedingCtx is like [Client.SetSuperSeeding] but uses ctx for the underlying requests.
+func (c *Client) SetSuperSeedingCtx(ctx context.Context, hashes []string, enable bool) (err error) {
 	params := url.Values{}
 	params.Add("hashes", strings.Join(hashes, "|"))
 	params.Add("value", strconv.FormatBool(enable))
-	_, err = c.postReq("/api/v2/torrents/setSuperSeeding", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/setSuperSeeding", &params)
 	return
 }
 
@@ -1091,11 +1571,16 @@ It can move files too.
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#rename-file
 */
 func (c *Client) RenameFile(hash, oldPath, newPath string) (err error) {
+	return c.RenameFileCtx(context.Background(), hash, oldPath, newPath)
+}
+
+// RenameFileCtx is like [Client.RenameFile] but uses ctx for the underlying requests.
+func (c *Client) RenameFileCtx(ctx context.Context, hash, oldPath, newPath string) (err error) {
 	params := url.Values{}
 	params.Add("hash", hash)
 	params.Add("oldPath", oldPath)
 	params.Add("newPath", newPath)
-	_, err = c.postReq("/api/v2/torrents/renameFile", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/renameFile", &params)
 	return
 }
 
@@ -1117,10 +1602,15 @@ Requires knowing the torrent hash. You can get it from GetTorrentList
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#rename-folder
 */
 func (c *Client) RenameFolder(hash, oldPath, newPath string) (err error) {
+	return c.RenameFolderCtx(context.Background(), hash, oldPath, newPath)
+}
+
+// RenameFolderCtx is like [Client.RenameFolder] but uses ctx for the underlying requests.
+func (c *Client) RenameFolderCtx(ctx context.Context, hash, oldPath, newPath string) (err error) {
 	params := url.Values{}
 	params.Add("hash", hash)
 	params.Add("oldPath", oldPath)
 	params.Add("newPath", newPath)
-	_, err = c.postReq("/api/v2/torrents/renameFolder", &params)
+	_, err = c.postReq(ctx, "/api/v2/torrents/renameFolder", &params)
 	return
 }
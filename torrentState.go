@@ -0,0 +1,69 @@
+package qbittorrent
+
+// normalize returns the qBittorrent 5.x name of a state, the states are decoded as sent by the server so
+// comparisons with the 4.x names keep working, and the methods below accept both names.
+func (s TorrentState) normalize() TorrentState {
+	switch s {
+	case TorrentStatePausedUP:
+		return TorrentStateStoppedUP
+	case TorrentStatePausedDL:
+		return TorrentStateStoppedDL
+	}
+	return s
+}
+
+// IsActive reports whether the torrent is started and connected to the swarm: downloading, seeding, fetching metadata or stalled.
+// Queued, stopped, checking, moving and errored torrents are not active.
+func (s TorrentState) IsActive() bool {
+	switch s.normalize() {
+	case TorrentStateDownloading, TorrentStateForcedDL, TorrentStateStalledDL, TorrentStateMetaDL, TorrentStateForcedMetaDL,
+		TorrentStateUploading, TorrentStateForcedUP, TorrentStateStalledUP:
+		return true
+	}
+	return false
+}
+
+// IsComplete reports whether the torrent has finished downloading, whatever it's doing now.
+func (s TorrentState) IsComplete() bool {
+	switch s.normalize() {
+	case TorrentStateUploading, TorrentStateForcedUP, TorrentStateStalledUP, TorrentStateQueuedUP, TorrentStateCheckingUP, TorrentStateStoppedUP:
+		return true
+	}
+	return false
+}
+
+// IsErrored reports whether an error occurred or the files of the torrent are missing.
+func (s TorrentState) IsErrored() bool {
+	return s == TorrentStateError || s == TorrentStateMissingFiles
+}
+
+// IsStopped reports whether the torrent was stopped (paused before qBittorrent 5.0).
+func (s TorrentState) IsStopped() bool {
+	switch s.normalize() {
+	case TorrentStateStoppedUP, TorrentStateStoppedDL:
+		return true
+	}
+	return false
+}
+
+// IsChecking reports whether the files of the torrent or its resume data are being checked.
+func (s TorrentState) IsChecking() bool {
+	return s == TorrentStateCheckingUP || s == TorrentStateCheckingDL || s == TorrentStateCheckingResumeData
+}
+
+// UnmarshalText accepts the filters of every qBittorrent version, the 4.x names are normalized to the 5.x ones
+// ("paused" becomes [FilterStopped] and "resumed" becomes [FilterRunning]).
+func (f *Filters) UnmarshalText(text []byte) error {
+	*f = Filters(text).normalize()
+	return nil
+}
+
+func (f Filters) normalize() Filters {
+	switch f {
+	case FilterPaused:
+		return FilterStopped
+	case FilterResumed:
+		return FilterRunning
+	}
+	return f
+}
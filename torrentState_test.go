@@ -0,0 +1,53 @@
+package qbittorrent_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+func TestTorrentStateDecoding(t *testing.T) {
+	for _, state := range []qbittorrent.TorrentState{qbittorrent.TorrentStatePausedUP, qbittorrent.TorrentStateStoppedUP} {
+		var torrent qbittorrent.TorrentListResponse
+		err := json.Unmarshal([]byte(`{"state":"`+string(state)+`"}`), &torrent)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the state is kept as sent, so comparisons with the names of the server version match
+		if torrent.State != state {
+			t.Errorf("decoded %q as %q", state, torrent.State)
+		}
+		if !torrent.State.IsStopped() || !torrent.State.IsComplete() || torrent.State.IsActive() {
+			t.Errorf("%q: got IsStopped=%v IsComplete=%v IsActive=%v", state, torrent.State.IsStopped(), torrent.State.IsComplete(), torrent.State.IsActive())
+		}
+	}
+}
+
+func TestTorrentStateClassification(t *testing.T) {
+	tests := []struct {
+		state                                     qbittorrent.TorrentState
+		active, complete, errored, stopped, check bool
+	}{
+		{qbittorrent.TorrentStateDownloading, true, false, false, false, false},
+		{qbittorrent.TorrentStateMetaDL, true, false, false, false, false},
+		{qbittorrent.TorrentStateStalledUP, true, true, false, false, false},
+		{qbittorrent.TorrentStateQueuedDL, false, false, false, false, false},
+		{qbittorrent.TorrentStatePausedDL, false, false, false, true, false},
+		{qbittorrent.TorrentStateStoppedDL, false, false, false, true, false},
+		{qbittorrent.TorrentStateCheckingUP, false, true, false, false, true},
+		{qbittorrent.TorrentStateCheckingResumeData, false, false, false, false, true},
+		{qbittorrent.TorrentStateMissingFiles, false, false, true, false, false},
+		{qbittorrent.TorrentStateError, false, false, true, false, false},
+	}
+
+	for _, test := range tests {
+		state := test.state
+		if state.IsActive() != test.active || state.IsComplete() != test.complete || state.IsErrored() != test.errored ||
+			state.IsStopped() != test.stopped || state.IsChecking() != test.check {
+			t.Errorf("%q: got active=%v complete=%v errored=%v stopped=%v checking=%v", state,
+				state.IsActive(), state.IsComplete(), state.IsErrored(), state.IsStopped(), state.IsChecking())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package qbittorrent
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"strconv"
@@ -16,7 +17,12 @@ This method returns info you usually see in qBt status bar.
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-global-transfer-info
 */
 func (c *Client) GetGlobalTransferInfo() (results TransferInfoResponse, err error) {
-	body, err := c.getReq("/api/v2/transfer/info", nil)
+	return c.GetGlobalTransferInfoCtx(context.Background())
+}
+
+// GetGlobalTransferInfoCtx is like [Client.GetGlobalTransferInfo] but uses ctx for the underlying requests.
+func (c *Client) GetGlobalTransferInfoCtx(ctx context.Context) (results TransferInfoResponse, err error) {
+	body, err := c.getReq(ctx, "/api/v2/transfer/info", nil)
 	if err != nil {
 		return
 	}
@@ -34,7 +40,12 @@ func (c *Client) GetGlobalTransferInfo() (results TransferInfoResponse, err erro
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-alternative-speed-limits-state
 */
 func (c *Client) GetAlternativeSpeedLimitsState() (results AlternativeSpeedLimitsStatus, err error) {
-	body, err := c.getReq("/api/v2/transfer/speedLimitsMode", nil)
+	return c.GetAlternativeSpeedLimitsStateCtx(context.Background())
+}
+
+// GetAlternativeSpeedLimitsStateCtx is like [Client.GetAlternativeSpeedLimitsState] but uses ctx for the underlying requests.
+func (c *Client) GetAlternativeSpeedLimitsStateCtx(ctx context.Context) (results AlternativeSpeedLimitsStatus, err error) {
+	body, err := c.getReq(ctx, "/api/v2/transfer/speedLimitsMode", nil)
 	if err != nil {
 		return
 	}
@@ -49,7 +60,12 @@ func (c *Client) GetAlternativeSpeedLimitsState() (results AlternativeSpeedLimit
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#toggle-alternative-speed-limits
 */
 func (c *Client) ToggleAlternativeSpeedLimits() (err error) {
-	_, err = c.postReq("/api/v2/transfer/toggleSpeedLimitsMode", nil)
+	return c.ToggleAlternativeSpeedLimitsCtx(context.Background())
+}
+
+// ToggleAlternativeSpeedLimitsCtx is like [Client.ToggleAlternativeSpeedLimits] but uses ctx for the underlying requests.
+func (c *Client) ToggleAlternativeSpeedLimitsCtx(ctx context.Context) (err error) {
+	_, err = c.postReq(ctx, "/api/v2/transfer/toggleSpeedLimitsMode", nil)
 	if err != nil {
 		return
 	}
@@ -66,7 +82,12 @@ func (c *Client) ToggleAlternativeSpeedLimits() (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-global-download-limit
 */
 func (c *Client) GetGlobalDownloadLimit() (results int, err error) {
-	body, err := c.getReq("/api/v2/transfer/downloadLimit", nil)
+	return c.GetGlobalDownloadLimitCtx(context.Background())
+}
+
+// GetGlobalDownloadLimitCtx is like [Client.GetGlobalDownloadLimit] but uses ctx for the underlying requests.
+func (c *Client) GetGlobalDownloadLimitCtx(ctx context.Context) (results int, err error) {
+	body, err := c.getReq(ctx, "/api/v2/transfer/downloadLimit", nil)
 	if err != nil {
 		return
 	}
@@ -84,9 +105,14 @@ func (c *Client) GetGlobalDownloadLimit() (results int, err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-global-download-limit
 */
 func (c *Client) SetGlobalDownloadLimit(limit int) (err error) {
+	return c.SetGlobalDownloadLimitCtx(context.Background(), limit)
+}
+
+// SetGlobalDownloadLimitCtx is like [Client.SetGlobalDownloadLimit] but uses ctx for the underlying requests.
+func (c *Client) SetGlobalDownloadLimitCtx(ctx context.Context, limit int) (err error) {
 	params := url.Values{}
 	params.Add("limit", strconv.Itoa(limit))
-	_, err = c.postReq("/api/v2/transfer/setDownloadLimit", &params)
+	_, err = c.postReq(ctx, "/api/v2/transfer/setDownloadLimit", &params)
 	return
 }
 
@@ -100,7 +126,12 @@ func (c *Client) SetGlobalDownloadLimit(limit int) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#get-global-upload-limit
 */
 func (c *Client) GetGlobalUploadLimit() (results int, err error) {
-	body, err := c.getReq("/api/v2/transfer/uploadLimit", nil)
+	return c.GetGlobalUploadLimitCtx(context.Background())
+}
+
+// GetGlobalUploadLimitCtx is like [Client.GetGlobalUploadLimit] but uses ctx for the underlying requests.
+func (c *Client) GetGlobalUploadLimitCtx(ctx context.Context) (results int, err error) {
+	body, err := c.getReq(ctx, "/api/v2/transfer/uploadLimit", nil)
 	if err != nil {
 		return
 	}
@@ -118,9 +149,14 @@ func (c *Client) GetGlobalUploadLimit() (results int, err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#set-global-upload-limit
 */
 func (c *Client) SetGlobalUploadLimit(limit int) (err error) {
+	return c.SetGlobalUploadLimitCtx(context.Background(), limit)
+}
+
+// SetGlobalUploadLimitCtx is like [Client.SetGlobalUploadLimit] but uses ctx for the underlying requests.
+func (c *Client) SetGlobalUploadLimitCtx(ctx context.Context, limit int) (err error) {
 	params := url.Values{}
 	params.Add("limit", strconv.Itoa(limit))
-	_, err = c.postReq("/api/v2/transfer/setUploadLimit", &params)
+	_, err = c.postReq(ctx, "/api/v2/transfer/setUploadLimit", &params)
 	return
 }
 
@@ -134,8 +170,13 @@ func (c *Client) SetGlobalUploadLimit(limit int) (err error) {
 https://github.com/qbittorrent/qBittorrent/wiki/WebUI-API-(qBittorrent-4.1)#ban-peers
 */
 func (c *Client) BanPeers(peers []string) (err error) {
+	return c.BanPeersCtx(context.Background(), peers)
+}
+
+// BanPeersCtx is like [Client.BanPeers] but uses ctx for the underlying requests.
+func (c *Client) BanPeersCtx(ctx context.Context, peers []string) (err error) {
 	params := url.Values{}
 	params.Add("peers", strings.Join(peers, "|"))
-	_, err = c.postReq("/api/v2/transfer/banPeers", &params)
+	_, err = c.postReq(ctx, "/api/v2/transfer/banPeers", &params)
 	return
 }
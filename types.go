@@ -7,14 +7,21 @@ const (
 	FilterDownloading        Filters = "downloading"
 	FilterSeeding            Filters = "seeding"
 	FilterCompleted          Filters = "completed"
-	FilterPaused             Filters = "paused"
+	FilterRunning            Filters = "running" // Since qBittorrent 5.0, translated to "resumed" for older servers
+	FilterStopped            Filters = "stopped" // Since qBittorrent 5.0, translated to "paused" for older servers
 	FilterActive             Filters = "active"
 	FilterInactive           Filters = "inactive"
-	FilterResumed            Filters = "resumed"
 	FilterStalled            Filters = "stalled"
 	FilterStalledUploading   Filters = "stalled_uploading"
 	FilterStalledDownloading Filters = "stalled_downloading"
+	FilterChecking           Filters = "checking"
+	FilterMoving             Filters = "moving"
 	FilterErrored            Filters = "errored"
+
+	// Deprecated: qBittorrent 5.0 renamed it, use [FilterStopped].
+	FilterPaused Filters = "paused"
+	// Deprecated: qBittorrent 5.0 renamed it, use [FilterRunning].
+	FilterResumed Filters = "resumed"
 )
 
 type TorrentState string
@@ -23,7 +30,7 @@ const (
 	TorrentStateError              TorrentState = "error"              // Some error occurred, applies to paused torrents
 	TorrentStateMissingFiles       TorrentState = "missingFiles"       // Torrent data files is missing
 	TorrentStateUploading          TorrentState = "uploading"          // Torrent is being seeded and data is being transferred
-	TorrentStatePausedUP           TorrentState = "pausedUP"           // Torrent is paused and has finished downloading
+	TorrentStateStoppedUP          TorrentState = "stoppedUP"          // Torrent is stopped and has finished downloading
 	TorrentStateQueuedUP           TorrentState = "queuedUP"           // Queuing is enabled and torrent is queued for upload
 	TorrentStateStalledUP          TorrentState = "stalledUP"          // Torrent is being seeded, but no connection were made
 	TorrentStateCheckingUP         TorrentState = "checkingUP"         // Torrent has finished downloading and is being checked
@@ -31,7 +38,8 @@ const (
 	TorrentStateAllocating         TorrentState = "allocating"         // Torrent is allocating disk space for download
 	TorrentStateDownloading        TorrentState = "downloading"        // Torrent is being downloaded and data is being transferred
 	TorrentStateMetaDL             TorrentState = "metaDL"             // Torrent has just started downloading and is fetching metadata
-	TorrentStatePausedDL           TorrentState = "pausedDL"           // Torrent is paused and has NOT finished downloading
+	TorrentStateForcedMetaDL       TorrentState = "forcedMetaDL"       // Same as metaDL, but the torrent was forced to start
+	TorrentStateStoppedDL          TorrentState = "stoppedDL"          // Torrent is stopped and has NOT finished downloading
 	TorrentStateQueuedDL           TorrentState = "queuedDL"           // Queuing is enabled and torrent is queued for download
 	TorrentStateStalledDL          TorrentState = "stalledDL"          // Torrent is being downloaded, but no connection were made
 	TorrentStateCheckingDL         TorrentState = "checkingDL"         // Same as checkingUP, but torrent has NOT finished downloading
@@ -39,6 +47,37 @@ const (
 	TorrentStateCheckingResumeData TorrentState = "checkingResumeData" // Checking resume data on qBt startup
 	TorrentStateMoving             TorrentState = "moving"             // Torrent is moving to another location
 	TorrentStateUnknown            TorrentState = "unknown"            // Unknown status
+
+	// States returned before qBittorrent 5.0, which renamed them to stoppedUP and stoppedDL.
+	// Use [TorrentState.IsStopped] to match both names.
+	TorrentStatePausedUP TorrentState = "pausedUP" // Torrent is paused and has finished downloading
+	TorrentStatePausedDL TorrentState = "pausedDL" // Torrent is paused and has NOT finished downloading
+)
+
+type ContentLayout string
+
+const (
+	ContentLayoutOriginal    ContentLayout = "Original"    // Keep the layout of the torrent
+	ContentLayoutSubfolder   ContentLayout = "Subfolder"   // Always create a folder named after the torrent
+	ContentLayoutNoSubfolder ContentLayout = "NoSubfolder" // Don't create the root folder of the torrent
+)
+
+type StopCondition string
+
+const (
+	StopConditionNone             StopCondition = "None"             // Don't stop the torrent
+	StopConditionMetadataReceived StopCondition = "MetadataReceived" // Stop the torrent when its metadata is received
+	StopConditionFilesChecked     StopCondition = "FilesChecked"     // Stop the torrent after its files are checked
+)
+
+type ShareLimitAction string
+
+const (
+	ShareLimitActionDefault            ShareLimitAction = "Default"            // Use the global setting
+	ShareLimitActionStop               ShareLimitAction = "Stop"               // Stop the torrent
+	ShareLimitActionRemove             ShareLimitAction = "Remove"             // Remove the torrent, keeping its files
+	ShareLimitActionRemoveWithContent  ShareLimitAction = "RemoveWithContent"  // Remove the torrent and its files
+	ShareLimitActionEnableSuperSeeding ShareLimitAction = "EnableSuperSeeding" // Enable super seeding
 )
 
 type SchedulerDays int
@@ -425,15 +464,44 @@ type GetPeerLogResponse struct {
 }
 
 type SyncMainDataResponse struct {
-	Rid               int                   `json:"rid"`                // Response ID
-	FullUpdate        bool                  `json:"full_update"`        // Whether the response contains all the data or partial data
-	Torrents          TorrentListResponse   `json:"torrents"`           // Property: torrent hash, value: same as torrent list
-	TorrentsRemoved   []string              `json:"torrents_removed"`   // List of hashes of torrents removed since last request
-	Categories        map[string]Category   `json:"categories"`         // Info for categories added since last request
-	CategoriesRemoved []map[string]Category `json:"categories_removed"` // List of categories removed since last request
-	Tags              []string              `json:"tags"`               // List of tags added since last request
-	TagsRemoved       []string              `json:"tags_removed"`       // array	List of tags removed since last request
-	ServerState       ServerState           `json:"server_state"`       // object	Global transfer info
+	Rid               int                            `json:"rid"`                // Response ID
+	FullUpdate        bool                           `json:"full_update"`        // Whether the response contains all the data or partial data
+	Torrents          map[string]TorrentListResponse `json:"torrents"`           // Property: torrent hash, value: same as torrent list. Partial updates only contain the changed fields
+	TorrentsRemoved   []string                       `json:"torrents_removed"`   // List of hashes of torrents removed since last request
+	Categories        map[string]Category            `json:"categories"`         // Info for categories added since last request
+	CategoriesRemoved []string                       `json:"categories_removed"` // List of categories removed since last request
+	Tags              []string                       `json:"tags"`               // List of tags added since last request
+	TagsRemoved       []string                       `json:"tags_removed"`       // List of tags removed since last request
+	Trackers          map[string][]string            `json:"trackers"`           // Property: tracker URL, value: list of torrent hashes using the tracker
+	TrackersRemoved   []string                       `json:"trackers_removed"`   // List of tracker URLs removed since last request
+	ServerState       ServerState                    `json:"server_state"`       // Global transfer info
+}
+
+type SyncTorrentPeersResponse struct {
+	Rid          int                    `json:"rid"`           // Response ID
+	FullUpdate   bool                   `json:"full_update"`   // Whether the response contains all the data or partial data
+	ShowFlags    bool                   `json:"show_flags"`    // Whether the peer flags should be shown
+	Peers        map[string]TorrentPeer `json:"peers"`         // Property: peer address ("ip:port"), value: peer info. Partial updates only contain the changed fields
+	PeersRemoved []string               `json:"peers_removed"` // List of peers removed since last request
+}
+
+type TorrentPeer struct {
+	IP           string  `json:"ip"`             // IP address of the peer
+	Port         int     `json:"port"`           // Port of the peer
+	Client       string  `json:"client"`         // Client name and version reported by the peer
+	PeerIdClient string  `json:"peer_id_client"` // Client guessed from the peer ID
+	Flags        string  `json:"flags"`          // Space separated peer flags (e.g. "D X E")
+	FlagsDesc    string  `json:"flags_desc"`     // Description of the peer flags, one per line
+	Progress     float64 `json:"progress"`       // Peer progress (percentage/100)
+	DlSpeed      int64   `json:"dl_speed"`       // Download speed from the peer (bytes/s)
+	UpSpeed      int64   `json:"up_speed"`       // Upload speed to the peer (bytes/s)
+	Downloaded   int64   `json:"downloaded"`     // Amount of data downloaded from the peer (bytes)
+	Uploaded     int64   `json:"uploaded"`       // Amount of data uploaded to the peer (bytes)
+	Relevance    float64 `json:"relevance"`      // Fraction of the peer's pieces we don't have (percentage/100)
+	Connection   string  `json:"connection"`     // Connection type (e.g. "BT", "μTP", "Web")
+	Country      string  `json:"country"`        // Country of the peer, empty if geolocation is disabled
+	CountryCode  string  `json:"country_code"`   // ISO 3166-1 alpha-2 country code of the peer, empty if geolocation is disabled
+	Files        string  `json:"files"`          // Files the peer is downloading/uploading, one per line
 }
 
 type Category struct {
@@ -600,3 +668,45 @@ type SearchCategory struct {
 	Id   string `json:"id"`
 	Name string `json:"name"`
 }
+
+type TorrentFormat string
+
+const (
+	TorrentFormatV1     TorrentFormat = "v1"     // BitTorrent v1, SHA-1 info hash
+	TorrentFormatV2     TorrentFormat = "v2"     // BitTorrent v2, SHA-256 info hash
+	TorrentFormatHybrid TorrentFormat = "hybrid" // Both v1 and v2, compatible with every client
+)
+
+type TorrentCreatorStatus string
+
+const (
+	TorrentCreatorStatusQueued   TorrentCreatorStatus = "Queued"
+	TorrentCreatorStatusRunning  TorrentCreatorStatus = "Running"
+	TorrentCreatorStatusFinished TorrentCreatorStatus = "Finished"
+	TorrentCreatorStatusFailed   TorrentCreatorStatus = "Failed"
+)
+
+type TorrentCreatorTaskId struct {
+	TaskId string `json:"taskID"` // ID of the torrent creation task
+}
+
+type TorrentCreatorTask struct {
+	TaskId              string               `json:"taskID"`              // ID of the task
+	SourcePath          string               `json:"sourcePath"`          // File or folder the torrent is created from
+	TorrentFilePath     string               `json:"torrentFilePath"`     // Where the .torrent file is saved on the server, empty when it's only kept in memory
+	PieceSize           int                  `json:"pieceSize"`           // Piece size in bytes, 0 for automatic
+	Private             bool                 `json:"private"`             // Whether the torrent is private
+	Format              TorrentFormat        `json:"format"`              // Torrent format, see [TorrentFormat]. Missing when qBittorrent is built with libtorrent 1.x
+	OptimizeAlignment   bool                 `json:"optimizeAlignment"`   // Whether the file alignment is optimized, only used by the v1 format
+	PaddedFileSizeLimit int                  `json:"paddedFileSizeLimit"` // Files smaller than this are not aligned, -1 for no limit
+	Comment             string               `json:"comment"`             // Torrent comment
+	Source              string               `json:"source"`              // Torrent source field
+	Trackers            []string             `json:"trackers"`            // Tracker URLs
+	UrlSeeds            []string             `json:"urlSeeds"`            // Web seed URLs
+	Status              TorrentCreatorStatus `json:"status"`              // Task status, see [TorrentCreatorStatus]
+	Progress            float64              `json:"progress"`            // Progress in percent (0-100)
+	ErrorMessage        string               `json:"errorMessage"`        // Why the task failed
+	TimeAdded           string               `json:"timeAdded"`           // When the task was added
+	TimeStarted         string               `json:"timeStarted"`         // When the task started, empty while queued
+	TimeFinished        string               `json:"timeFinished"`        // When the task finished or failed
+}
@@ -0,0 +1,191 @@
+package qbittorrent
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// default qBittorrent WebUI refresh interval, used when the server doesn't report one
+const defaultPollInterval = 1500 * time.Millisecond
+
+type EventType string
+
+const (
+	EventTorrentAdded       EventType = "torrent_added"        // A torrent was added
+	EventTorrentRemoved     EventType = "torrent_removed"      // A torrent was removed, [Event.Torrent] holds its last known data
+	EventStateChanged       EventType = "state_changed"        // The state of a torrent changed, see [Event.Previous] for the old state
+	EventCompleted          EventType = "completed"            // A torrent finished downloading
+	EventCategoryChanged    EventType = "category_changed"     // The category of a torrent changed
+	EventTagsChanged        EventType = "tags_changed"         // The tags of a torrent changed
+	EventTrackerChanged     EventType = "tracker_changed"      // The working tracker of a torrent changed
+	EventSpeedLimitChanged  EventType = "speed_limit_changed"  // The speed limits of a torrent changed, or the global ones if [Event.Hash] is empty
+	EventServerStateChanged EventType = "server_state_changed" // The global transfer info changed
+)
+
+// Event is a change detected by [Client.Watch].
+type Event struct {
+	Type                EventType           // Type of the change
+	Hash                string              // Torrent hash, empty for global events
+	Torrent             TorrentListResponse // Torrent after the change
+	Previous            TorrentListResponse // Torrent before the change, empty for EventTorrentAdded
+	ServerState         ServerState         // Global transfer info after the change
+	PreviousServerState ServerState         // Global transfer info before the change
+}
+
+type WatchOptions struct {
+	Interval time.Duration // Poll interval. Defaults to the server's refresh interval (server_state.refresh_interval)
+}
+
+/*
+Watch polls `/api/v2/sync/maindata` using a [MainDataStore] and emits an [Event] for every torrent lifecycle change.
+
+The first update is used as a baseline, torrents that already exist don't produce [EventTorrentAdded].
+
+Both channels are closed when ctx is done or when an update fails, in which case the error is sent on the
+error channel first. Cancelling ctx is not reported as an error.
+
+# Example
+
+	events, errs := client.Watch(ctx, nil)
+	for event := range events {
+	 if event.Type == qbittorrent.EventCompleted {
+	  fmt.Println("completed:", event.Torrent.Name)
+	 }
+	}
+	if err := <-errs; err != nil {
+	 panic(err)
+	}
+*/
+func (c *Client) Watch(ctx context.Context, opts *WatchOptions) (<-chan Event, <-chan error) {
+	if opts == nil {
+		opts = &WatchOptions{}
+	}
+
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(events)
+
+		store := c.NewMainDataStore()
+		err := store.Update(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				errs <- err
+			}
+			return
+		}
+		prev := store.Snapshot()
+
+		for {
+			interval := opts.Interval
+			if interval <= 0 {
+				interval = time.Duration(prev.ServerState.RefreshInterval) * time.Millisecond
+			}
+			if interval <= 0 {
+				interval = defaultPollInterval
+			}
+
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			err = store.Update(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+
+			cur := store.Snapshot()
+			for _, event := range diffMainData(prev, cur) {
+				select {
+				case <-ctx.Done():
+					return
+				case events <- event:
+				}
+			}
+			prev = cur
+		}
+	}()
+
+	return events, errs
+}
+
+// diffMainData returns the events between two snapshots, ordered by torrent hash
+func diffMainData(prev, cur MainData) (events []Event) {
+	newEvent := func(eventType EventType, hash string, torrent, previous TorrentListResponse) Event {
+		return Event{
+			Type:                eventType,
+			Hash:                hash,
+			Torrent:             torrent,
+			Previous:            previous,
+			ServerState:         cur.ServerState,
+			PreviousServerState: prev.ServerState,
+		}
+	}
+
+	hashes := make([]string, 0, len(cur.Torrents))
+	for hash := range cur.Torrents {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	for _, hash := range hashes {
+		torrent := cur.Torrents[hash]
+		old, ok := prev.Torrents[hash]
+		if !ok {
+			events = append(events, newEvent(EventTorrentAdded, hash, torrent, TorrentListResponse{}))
+			continue
+		}
+
+		if torrent.State != old.State {
+			events = append(events, newEvent(EventStateChanged, hash, torrent, old))
+		}
+		if old.Progress < 1 && torrent.Progress >= 1 {
+			events = append(events, newEvent(EventCompleted, hash, torrent, old))
+		}
+		if torrent.Category != old.Category {
+			events = append(events, newEvent(EventCategoryChanged, hash, torrent, old))
+		}
+		if torrent.Tags != old.Tags {
+			events = append(events, newEvent(EventTagsChanged, hash, torrent, old))
+		}
+		if torrent.Tracker != old.Tracker {
+			events = append(events, newEvent(EventTrackerChanged, hash, torrent, old))
+		}
+		if torrent.DlLimit != old.DlLimit || torrent.UpLimit != old.UpLimit {
+			events = append(events, newEvent(EventSpeedLimitChanged, hash, torrent, old))
+		}
+	}
+
+	removed := make([]string, 0)
+	for hash := range prev.Torrents {
+		if _, ok := cur.Torrents[hash]; !ok {
+			removed = append(removed, hash)
+		}
+	}
+	sort.Strings(removed)
+
+	for _, hash := range removed {
+		events = append(events, newEvent(EventTorrentRemoved, hash, prev.Torrents[hash], prev.Torrents[hash]))
+	}
+
+	if cur.ServerState.DLRateLimit != prev.ServerState.DLRateLimit ||
+		cur.ServerState.UPRateLimit != prev.ServerState.UPRateLimit ||
+		cur.ServerState.UseAltSpeedLimits != prev.ServerState.UseAltSpeedLimits {
+		events = append(events, newEvent(EventSpeedLimitChanged, "", TorrentListResponse{}, TorrentListResponse{}))
+	}
+	if cur.ServerState != prev.ServerState {
+		events = append(events, newEvent(EventServerStateChanged, "", TorrentListResponse{}, TorrentListResponse{}))
+	}
+
+	return
+}
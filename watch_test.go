@@ -0,0 +1,105 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+	"github.com/alabsi91/qbittorrent-webapi-go/qbittest"
+)
+
+const mainDataEndpoint = "/api/v2/sync/maindata"
+
+// waitRequests waits until the server received n requests to the endpoint
+func waitRequests(t *testing.T, srv *qbittest.Server, endpoint string, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for srv.Requests(endpoint) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d requests to %s, want %d", srv.Requests(endpoint), endpoint, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func nextEvent(t *testing.T, events <-chan qbittorrent.Event) qbittorrent.Event {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("the events channel was closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+	return qbittorrent.Event{}
+}
+
+func TestWatch(t *testing.T) {
+	srv, client := newTestClient(t)
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: ubuntuHash, Name: "ubuntu", State: qbittorrent.TorrentStateDownloading, Progress: 0.5})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs := client.Watch(ctx, &qbittorrent.WatchOptions{Interval: 5 * time.Millisecond})
+
+	// the baseline is applied once the next update is requested, the torrents it has are not reported
+	waitRequests(t, srv, mainDataEndpoint, 2)
+
+	srv.AddTorrent(qbittorrent.TorrentListResponse{Hash: debianHash, Name: "debian"})
+	if event := nextEvent(t, events); event.Type != qbittorrent.EventTorrentAdded || event.Hash != debianHash || event.Torrent.Name != "debian" {
+		t.Fatalf("got %+v, want debian added", event)
+	}
+
+	srv.UpdateTorrent(ubuntuHash, func(info *qbittorrent.TorrentListResponse) {
+		info.State = qbittorrent.TorrentStateUploading
+		info.Progress = 1
+	})
+	event := nextEvent(t, events)
+	if event.Type != qbittorrent.EventStateChanged || event.Hash != ubuntuHash ||
+		event.Previous.State != qbittorrent.TorrentStateDownloading || event.Torrent.State != qbittorrent.TorrentStateUploading {
+		t.Fatalf("got %+v, want the state of ubuntu changed", event)
+	}
+	if event := nextEvent(t, events); event.Type != qbittorrent.EventCompleted || event.Hash != ubuntuHash || event.Previous.Progress != 0.5 {
+		t.Fatalf("got %+v, want ubuntu completed", event)
+	}
+
+	srv.RemoveTorrent(debianHash)
+	if event := nextEvent(t, events); event.Type != qbittorrent.EventTorrentRemoved || event.Hash != debianHash || event.Torrent.Name != "debian" {
+		t.Fatalf("got %+v, want debian removed", event)
+	}
+
+	// cancelling ctx closes both channels without an error
+	cancel()
+	for range events {
+	}
+	if err, ok := <-errs; ok || err != nil {
+		t.Fatalf("got error %v after cancelling", err)
+	}
+}
+
+func TestWatchError(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	events, errs := client.Watch(context.Background(), &qbittorrent.WatchOptions{Interval: 5 * time.Millisecond})
+
+	waitRequests(t, srv, mainDataEndpoint, 1)
+	srv.FailNext(mainDataEndpoint, 1, http.StatusInternalServerError, "Internal Server Error")
+
+	for range events {
+	}
+	var apiErr *qbittorrent.APIError
+	if err := <-errs; !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("got error %v, want a 500 APIError", err)
+	}
+	if _, ok := <-errs; ok {
+		t.Fatal("the error channel was not closed")
+	}
+}
@@ -0,0 +1,69 @@
+package qbittorrent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+ReplaceWebSeedPrefix edits the web seeds of every torrent whose URL starts with oldPrefix, replacing the
+prefix with newPrefix, e.g. to move all the torrents from a dead HTTP mirror to a new one.
+
+It returns the number of edited web seeds. A torrent that fails to be updated doesn't stop the others,
+the errors of all the failed torrents are joined in the returned error.
+
+An empty oldPrefix returns an error matching [ErrInvalidOptions].
+
+Requires WebAPI v2.11.3 (qBittorrent 5.1) or newer, see [Client.Capabilities].
+
+# Example
+
+	edited, err := client.ReplaceWebSeedPrefix(ctx, "http://old-mirror.example.org/", "https://mirror.example.org/")
+*/
+func (c *Client) ReplaceWebSeedPrefix(ctx context.Context, oldPrefix, newPrefix string) (edited int, err error) {
+	// an empty prefix would match every web seed
+	if oldPrefix == "" {
+		return 0, fmt.Errorf("%w: empty web seed prefix", ErrInvalidOptions)
+	}
+
+	err = c.requireAPIVersion(ctx, "editing web seeds", apiVersionWebSeedEditing)
+	if err != nil {
+		return
+	}
+
+	torrents, err := c.GetTorrentListCtx(ctx, nil)
+	if err != nil {
+		return
+	}
+
+	var errs []error
+	for _, torrent := range torrents {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+
+		seeds, err := c.GetTorrentWebSeedsCtx(ctx, torrent.Hash)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("torrent %s: %w", torrent.Hash, err))
+			continue
+		}
+
+		for _, seed := range seeds {
+			if !strings.HasPrefix(seed.Url, oldPrefix) {
+				continue
+			}
+
+			err = c.EditWebSeedCtx(ctx, torrent.Hash, seed.Url, newPrefix+strings.TrimPrefix(seed.Url, oldPrefix))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("torrent %s: %w", torrent.Hash, err))
+				continue
+			}
+			edited++
+		}
+	}
+
+	return edited, errors.Join(errs...)
+}
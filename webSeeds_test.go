@@ -0,0 +1,61 @@
+package qbittorrent_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	qbittorrent "github.com/alabsi91/qbittorrent-webapi-go"
+)
+
+func TestReplaceWebSeedPrefix(t *testing.T) {
+	_, client := newTestClientVersion(t, "2.11.3")
+
+	hashes := addTestTorrents(t, client, 2)
+	err := client.AddWebSeeds(hashes[0], []string{"http://old.example.org/a", "http://other.example.org/b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.AddWebSeeds(hashes[1], []string{"http://old.example.org/c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited, err := client.ReplaceWebSeedPrefix(context.Background(), "http://old.example.org/", "https://new.example.org/")
+	if err != nil || edited != 2 {
+		t.Fatalf("got %d edited web seeds, %v, want 2", edited, err)
+	}
+
+	want := [][]string{
+		{"https://new.example.org/a", "http://other.example.org/b"},
+		{"https://new.example.org/c"},
+	}
+	for i, hash := range hashes {
+		seeds, err := client.GetTorrentWebSeeds(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var urls []string
+		for _, seed := range seeds {
+			urls = append(urls, seed.Url)
+		}
+		if !reflect.DeepEqual(urls, want[i]) {
+			t.Fatalf("torrent %d: got web seeds %v, want %v", i, urls, want[i])
+		}
+	}
+}
+
+func TestReplaceWebSeedPrefixEmpty(t *testing.T) {
+	srv, client := newTestClientVersion(t, "2.11.3")
+	requests := srv.Requests(torrentsEndpoint)
+
+	edited, err := client.ReplaceWebSeedPrefix(context.Background(), "", "https://new.example.org/")
+	if !errors.Is(err, qbittorrent.ErrInvalidOptions) || edited != 0 {
+		t.Fatalf("got %d edited web seeds, %v, want ErrInvalidOptions", edited, err)
+	}
+	if srv.Requests(torrentsEndpoint) != requests {
+		t.Fatal("the torrents were listed with an empty prefix")
+	}
+}